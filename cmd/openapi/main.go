@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	handlers "zatrano/handlers/api"
+)
+
+// OpenAPI belgesini diske yazar; veritabanı veya .env gerektirmez.
+//
+//	go run ./cmd/openapi -out docs/openapi.json
+func main() {
+	out := flag.String("out", "docs/openapi.json", "Belgenin yazılacağı dosya; - verilirse standart çıktı")
+	flag.Parse()
+
+	body, err := handlers.Spec().JSON()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "OpenAPI belgesi üretilemedi:", err)
+		os.Exit(1)
+	}
+
+	if *out == "-" {
+		_, _ = os.Stdout.Write(body)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "Klasör oluşturulamadı:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, body, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "Belge yazılamadı:", err)
+		os.Exit(1)
+	}
+	fmt.Println("OpenAPI belgesi yazıldı:", *out)
+}
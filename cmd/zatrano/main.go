@@ -9,10 +9,14 @@ import (
 	"zatrano/configs/databaseconfig"
 	"zatrano/configs/fileconfig"
 	"zatrano/configs/logconfig"
+	"zatrano/configs/proxyconfig"
 	"zatrano/configs/sessionconfig"
+	"zatrano/middlewares"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/templatehelpers"
 	"zatrano/routes"
+	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/template/html/v2"
@@ -30,10 +34,17 @@ func main() {
 
 	logconfig.SLog.Debugw("Ortam değişkenleri yüklendi ve logger başlatıldı")
 
+	i18n.Init()
+
 	databaseconfig.InitDB()
 	defer databaseconfig.CloseDB()
 
 	sessionconfig.InitSession()
+	defer sessionconfig.CloseSession()
+
+	trashPurger := services.NewTrashPurger()
+	trashPurger.Start()
+	defer trashPurger.Close()
 
 	fileconfig.InitFileConfig()
 
@@ -44,28 +55,12 @@ func main() {
 	engine.AddFunc("getFlashMessages", flashmessages.GetFlashMessages)
 	engine.AddFuncMap(templatehelpers.TemplateHelpers())
 
-	app := fiber.New(fiber.Config{
-		Views: engine,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			message := "Internal Server Error"
-
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-				message = e.Message
-			}
-
-			logconfig.Log.Error("Fiber request error",
-				zap.Error(err),
-				zap.Int("status_code", code),
-				zap.String("method", c.Method()),
-				zap.String("path", c.Path()),
-				zap.String("ip", c.IP()),
-			)
-
-			return c.Status(code).JSON(fiber.Map{"error": message})
-		},
-	})
+	appConfig := fiber.Config{
+		Views:        engine,
+		ErrorHandler: middlewares.ErrorHandler,
+	}
+	proxyconfig.Apply(&appConfig)
+	app := fiber.New(appConfig)
 
 	app.Static("/", "./public")
 	app.Use(csrfconfig.SetupCSRF())
@@ -14,6 +14,7 @@ import (
 
 var csrfExemptPaths = []string{
 	// "rotalar",
+	"/api/", // API istekleri oturum çerezi değil Bearer token ile doğrulanır
 }
 
 func SetupCSRF() fiber.Handler {
@@ -33,7 +34,7 @@ func SetupCSRF() fiber.Handler {
 				zap.String("path", c.Path()),
 				zap.String("method", c.Method()),
 			)
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Güvenlik doğrulaması başarısız oldu. Lütfen sayfayı yenileyip tekrar deneyin.")
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.csrf_failed")
 			return c.Redirect("/auth/login", fiber.StatusSeeOther)
 		},
 		Next: func(c *fiber.Ctx) bool {
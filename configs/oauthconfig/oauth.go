@@ -0,0 +1,90 @@
+package oauthconfig
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/pkg/oauthprovider"
+)
+
+var (
+	registry     *oauthprovider.Registry
+	registryOnce sync.Once
+)
+
+// Registry, ortam değişkenlerinden yapılandırılmış sağlayıcı kayıt defterini döner.
+// Bir sağlayıcı yalnızca <SAĞLAYICI>_CLIENT_ID tanımlıysa etkinleşir.
+func Registry() *oauthprovider.Registry {
+	registryOnce.Do(func() {
+		if registry == nil {
+			registry = buildRegistry()
+		}
+	})
+	return registry
+}
+
+// SetRegistry, varsayılan kayıt defterini değiştirir; sahte sağlayıcılarla çalışırken kullanılır.
+func SetRegistry(r *oauthprovider.Registry) {
+	registry = r
+}
+
+func buildRegistry() *oauthprovider.Registry {
+	r := oauthprovider.NewRegistry()
+	client := &http.Client{
+		Timeout: time.Duration(envconfig.GetEnvAsInt("OAUTH_HTTP_TIMEOUT_SECONDS", 10)) * time.Second,
+	}
+
+	if cfg, ok := providerConfig("google", client); ok {
+		r.Register(oauthprovider.NewGoogle(cfg))
+	}
+	if cfg, ok := providerConfig("github", client); ok {
+		r.Register(oauthprovider.NewGitHub(cfg))
+	}
+	if cfg, ok := providerConfig("microsoft", client); ok {
+		r.Register(oauthprovider.NewMicrosoft(cfg, os.Getenv("MICROSOFT_TENANT")))
+	}
+	if cfg, ok := providerConfig("oidc", client); ok {
+		issuer := os.Getenv("OIDC_ISSUER_URL")
+		if issuer == "" {
+			logconfig.SLog.Warn("OIDC_CLIENT_ID tanımlı ancak OIDC_ISSUER_URL boş, OIDC sağlayıcısı devre dışı.")
+		} else {
+			r.Register(oauthprovider.NewOIDC("oidc", envconfig.GetEnvWithDefault("OIDC_DISPLAY_NAME", "OpenID Connect"), issuer, cfg))
+		}
+	}
+
+	for _, provider := range r.All() {
+		logconfig.SLog.Infof("OAuth sağlayıcısı etkin: %s", provider.Name())
+	}
+	return r
+}
+
+func providerConfig(name string, client *http.Client) (oauthprovider.Config, bool) {
+	prefix := strings.ToUpper(name)
+	clientID := os.Getenv(prefix + "_CLIENT_ID")
+	if clientID == "" {
+		return oauthprovider.Config{}, false
+	}
+
+	redirectURL := os.Getenv(prefix + "_REDIRECT_URI")
+	if redirectURL == "" {
+		redirectURL = strings.TrimRight(os.Getenv("APP_BASE_URL"), "/") + "/auth/" + name + "/callback"
+	}
+
+	var scopes []string
+	if raw := os.Getenv(prefix + "_SCOPES"); raw != "" {
+		scopes = strings.Fields(strings.ReplaceAll(raw, ",", " "))
+	}
+
+	return oauthprovider.Config{
+		ClientID:     clientID,
+		ClientSecret: os.Getenv(prefix + "_CLIENT_SECRET"),
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+		HTTPClient:   client,
+	}, true
+}
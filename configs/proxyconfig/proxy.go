@@ -0,0 +1,36 @@
+package proxyconfig
+
+import (
+	"strings"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Apply, uygulama bir yük dengeleyici veya ters proxy arkasında çalışıyorsa c.IP()'nin
+// istemci adresini döndürmesi için TRUSTED_PROXIES ve PROXY_HEADER ayarlarını fiber'a
+// işler. Başlık yalnızca TRUSTED_PROXIES içindeki adreslerden gelen isteklerde okunur;
+// liste boşsa başlık hiç dikkate alınmaz, aksi halde istemciler IP adreslerini taklit edebilirdi.
+func Apply(cfg *fiber.Config) {
+	var proxies []string
+	for _, proxy := range strings.Split(envconfig.GetEnvWithDefault("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	if len(proxies) == 0 {
+		return
+	}
+
+	cfg.ProxyHeader = envconfig.GetEnvWithDefault("PROXY_HEADER", fiber.HeaderXForwardedFor)
+	cfg.EnableTrustedProxyCheck = true
+	cfg.TrustedProxies = proxies
+
+	logconfig.Log.Info("Güvenilen proxy ayarları yüklendi",
+		zap.String("header", cfg.ProxyHeader),
+		zap.Strings("trusted_proxies", proxies),
+	)
+}
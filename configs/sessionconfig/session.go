@@ -4,14 +4,21 @@ import (
 	"encoding/gob"
 	"time"
 
+	"zatrano/configs/databaseconfig"
 	"zatrano/configs/envconfig"
 	"zatrano/configs/logconfig"
 	"zatrano/models"
+	"zatrano/pkg/sessionstore"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
+const (
+	SessionDriverMemory   = "memory"
+	SessionDriverPostgres = "postgres"
+)
+
 var Session *session.Store
 
 func InitSession() {
@@ -32,22 +39,46 @@ func createSessionStore() *session.Store {
 	sessionExpirationHours := envconfig.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)
 	cookieSecure := envconfig.IsProduction()
 
-	store := session.New(session.Config{
+	cfg := session.Config{
 		CookieHTTPOnly: false,
 		CookieSecure:   cookieSecure,
 		Expiration:     time.Duration(sessionExpirationHours) * time.Hour,
 		KeyLookup:      "cookie:session_id",
 		CookieSameSite: "Lax",
-	})
+	}
+
+	driver := envconfig.GetEnvWithDefault("SESSION_DRIVER", SessionDriverMemory)
+	switch driver {
+	case SessionDriverPostgres:
+		gcMinutes := envconfig.GetEnvAsInt("SESSION_GC_INTERVAL_MINUTES", 10)
+		cfg.Storage = sessionstore.NewPostgresStorage(databaseconfig.GetDB(), time.Duration(gcMinutes)*time.Minute)
+	case SessionDriverMemory:
+	default:
+		logconfig.SLog.Warnf("Bilinmeyen SESSION_DRIVER değeri '%s', bellek tabanlı session kullanılacak.", driver)
+		driver = SessionDriverMemory
+	}
 
-	logconfig.SLog.Info("Cookie tabanlı session sistemi %d saatlik süreyle yapılandırıldı.", sessionExpirationHours)
+	store := session.New(cfg)
+
+	logconfig.SLog.Infof("Session sistemi '%s' sürücüsü ve %d saatlik süreyle yapılandırıldı.", driver, sessionExpirationHours)
 	return store
 }
 
+func CloseSession() {
+	if Session == nil || Session.Storage == nil {
+		return
+	}
+	if err := Session.Storage.Close(); err != nil {
+		logconfig.SLog.Warnf("Session deposu kapatılırken hata oluştu: %v", err)
+	}
+}
+
 func registerGobTypes() {
 	gob.Register(models.UserType(""))
 	gob.Register(&models.User{})
-	logconfig.SLog.Debug("Session için gob türleri kaydedildi: models.UserType, *models.User")
+	gob.Register(map[string]string{})
+	gob.Register(map[string][]string{})
+	logconfig.SLog.Debug("Session için gob türleri kaydedildi: models.UserType, *models.User, form durumu")
 }
 
 func SessionStart(c *fiber.Ctx) (*session.Session, error) {
@@ -73,11 +104,31 @@ func GetUserTypeFromSession(sess *session.Session) (models.UserType, error) {
 	return userType, nil
 }
 
+// Lifetime, yapılandırılmış session süresini döner.
+func Lifetime() time.Duration {
+	if Session == nil {
+		return time.Duration(envconfig.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)) * time.Hour
+	}
+	return Session.Expiration
+}
+
+// DeleteSessionByID, başka bir cihaza ait oturumu depodan siler.
+func DeleteSessionByID(id string) error {
+	if Session == nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "session store not initialized")
+	}
+	return Session.Delete(id)
+}
+
 func GetUserIDFromSession(c *fiber.Ctx) (uint, error) {
 	sess, err := SessionStart(c)
 	if err != nil {
 		return 0, err
 	}
+	return SessionUserID(sess)
+}
+
+func SessionUserID(sess *session.Session) (uint, error) {
 	userIDValue := sess.Get("user_id")
 	switch v := userIDValue.(type) {
 	case uint:
@@ -11,17 +11,24 @@ import (
 func main() {
 	logconfig.InitLogger()
 	defer logconfig.SyncLogger()
-	migrateFlag := flag.Bool("migrate", false, "Veritabanı başlatma işlemini çalıştır (migrasyonları içerir)")
-	seedFlag := flag.Bool("seed", false, "Veritabanı başlatma işlemini çalıştır (seederları içerir)")
+	migrateFlag := flag.String("migrate", "", "Migrasyon komutu: up, down, status, redo, plan")
+	stepsFlag := flag.Int("steps", 1, "down ve redo için geri alınacak migrasyon sayısı")
+	seedFlag := flag.String("seed", "", "Çalıştırılacak seeder: ad, virgülle ayrılmış adlar ya da all")
 	flag.Parse()
 
+	// Bayraklar ilk konumsal argümanda durur; "-migrate down 2 -seed all" gibi bir
+	// kullanımda kalan bayraklar sessizce yok sayılmasın diye reddedilir.
+	if flag.NArg() > 0 {
+		logconfig.SLog.Fatalf("Beklenmeyen argümanlar: %v (adım sayısı için -steps N kullanın)", flag.Args())
+	}
+
 	databaseconfig.InitDB()
 	defer databaseconfig.CloseDB()
 
 	db := databaseconfig.GetDB()
 
 	logconfig.SLog.Info("Veritabanı başlatma işlemi çalıştırılıyor...")
-	database.Initialize(db, *migrateFlag, *seedFlag)
+	database.Initialize(db, *migrateFlag, *stepsFlag, *seedFlag)
 
 	logconfig.SLog.Info("Veritabanı başlatma işlemi tamamlandı.")
 }
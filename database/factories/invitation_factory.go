@@ -0,0 +1,71 @@
+package factories
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"zatrano/models"
+)
+
+var (
+	invitationCategories = []string{
+		"Düğün", "Nişan", "Kına Gecesi", "Söz", "Doğum Günü", "Baby Shower", "Sünnet",
+		"Mezuniyet", "Açılış", "Asker Eğlencesi",
+	}
+	cities = []string{
+		"İstanbul", "Ankara", "İzmir", "Bursa", "Antalya", "Konya", "Eskişehir", "Trabzon", "Gaziantep", "Muğla",
+	}
+	venues = []string{
+		"Düğün Salonu", "Kır Bahçesi", "Otel Balo Salonu", "Sahil Restoranı", "Konak Bahçesi", "Kültür Merkezi",
+	}
+)
+
+// InvitationFactory, geliştirme ortamı için verilen kullanıcılara ait sahte davetiyeler
+// üretir. Etkinlik tarihleri üretim anından itibaren gelecek bir yıla yayılır.
+type InvitationFactory struct {
+	rnd *rand.Rand
+	now time.Time
+}
+
+func NewInvitationFactory(seed int64) *InvitationFactory {
+	return &InvitationFactory{rnd: rand.New(rand.NewSource(seed)), now: time.Now()}
+}
+
+// Make, userID'ye ait bir davetiye üretir; overrides alanları üretimden sonra değiştirmek içindir.
+func (f *InvitationFactory) Make(userID uint, overrides ...func(*models.Invitation)) models.Invitation {
+	category := invitationCategories[f.rnd.Intn(len(invitationCategories))]
+	first := firstNames[f.rnd.Intn(len(firstNames))]
+	last := lastNames[f.rnd.Intn(len(lastNames))]
+	city := cities[f.rnd.Intn(len(cities))]
+	day := f.now.AddDate(0, 0, 7+f.rnd.Intn(358))
+	eventDate := time.Date(day.Year(), day.Month(), day.Day(), 12+f.rnd.Intn(9), 30*f.rnd.Intn(2), 0, 0, day.Location())
+
+	invitation := models.Invitation{
+		UserID:       userID,
+		Category:     category,
+		Title:        fmt.Sprintf("%s %s %s Davetiyesi", first, last, category),
+		Description:  fmt.Sprintf("Bu mutlu günümüzde sizleri de aramızda görmekten onur duyarız. %s ailesi", last),
+		EventDate:    eventDate,
+		Location:     fmt.Sprintf("%s %s, %s", last, venues[f.rnd.Intn(len(venues))], city),
+		ContactPhone: fmt.Sprintf("05%02d %03d %02d %02d", 30+f.rnd.Intn(26), f.rnd.Intn(1000), f.rnd.Intn(100), f.rnd.Intn(100)),
+	}
+	if f.rnd.Intn(4) == 0 {
+		invitation.OnlineLink = fmt.Sprintf("https://meet.example.com/%s-%d", asciiSlug(last), f.rnd.Intn(100000))
+	}
+	for _, override := range overrides {
+		override(&invitation)
+	}
+	return invitation
+}
+
+// MakeMany, her kullanıcı için 0 ile perUser arasında davetiye üretir.
+func (f *InvitationFactory) MakeMany(userIDs []uint, perUser int, overrides ...func(*models.Invitation)) []models.Invitation {
+	var invitations []models.Invitation
+	for _, userID := range userIDs {
+		for i := f.rnd.Intn(perUser + 1); i > 0; i-- {
+			invitations = append(invitations, f.Make(userID, overrides...))
+		}
+	}
+	return invitations
+}
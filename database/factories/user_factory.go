@@ -0,0 +1,75 @@
+// Package factories, seeder'ların kullandığı sahte kullanıcı ve davetiye üreticilerini içerir.
+package factories
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"zatrano/models"
+)
+
+var (
+	firstNames = []string{
+		"Ahmet", "Mehmet", "Mustafa", "Ali", "Hüseyin", "Hasan", "İbrahim", "Emre", "Burak", "Can",
+		"Oğuz", "Kerem", "Tolga", "Serkan", "Çağlar", "Ayşe", "Fatma", "Zeynep", "Elif", "Emine",
+		"Hatice", "Merve", "Büşra", "Özge", "Gülşen", "Şeyma", "Derya", "İrem", "Ebru", "Sevgi",
+	}
+	lastNames = []string{
+		"Yılmaz", "Kaya", "Demir", "Şahin", "Çelik", "Yıldız", "Yıldırım", "Öztürk", "Aydın", "Özdemir",
+		"Arslan", "Doğan", "Kılıç", "Aslan", "Çetin", "Kara", "Koç", "Kurt", "Özkan", "Şimşek",
+		"Polat", "Erdoğan", "Güneş", "Aktaş", "Bulut", "Keskin", "Ünal", "Tekin", "Başaran", "Uçar",
+	}
+	asciiReplacer = strings.NewReplacer(
+		"ç", "c", "ğ", "g", "ı", "i", "ö", "o", "ş", "s", "ü", "u",
+		"Ç", "c", "Ğ", "g", "İ", "i", "Ö", "o", "Ş", "s", "Ü", "u",
+	)
+)
+
+// UserFactory, geliştirme ortamı için Türkçe ad ve soyadlı sahte kullanıcılar üretir.
+// Aynı seed ile aynı kullanıcılar üretilir; e-postalar sıra numarasıyla tekilleşir ve
+// ayrılmış example.com alan adını kullanır.
+type UserFactory struct {
+	rnd            *rand.Rand
+	hashedPassword string
+	sequence       int
+}
+
+// NewUserFactory, tüm kullanıcılara verilecek bcrypt ile hash'lenmiş şifreyi alır;
+// hash her kullanıcı için yeniden hesaplanmaz.
+func NewUserFactory(seed int64, hashedPassword string) *UserFactory {
+	return &UserFactory{rnd: rand.New(rand.NewSource(seed)), hashedPassword: hashedPassword}
+}
+
+// Make, bir kullanıcı üretir; overrides alanları üretimden sonra değiştirmek içindir.
+func (f *UserFactory) Make(overrides ...func(*models.User)) models.User {
+	f.sequence++
+	first := firstNames[f.rnd.Intn(len(firstNames))]
+	last := lastNames[f.rnd.Intn(len(lastNames))]
+
+	user := models.User{
+		Name:          first + " " + last,
+		Email:         fmt.Sprintf("%s.%s%d@example.com", asciiSlug(first), asciiSlug(last), f.sequence),
+		Password:      f.hashedPassword,
+		Type:          models.Panel,
+		Status:        f.rnd.Intn(10) > 0,
+		EmailVerified: f.rnd.Intn(5) > 0,
+		Locale:        "tr",
+	}
+	for _, override := range overrides {
+		override(&user)
+	}
+	return user
+}
+
+func (f *UserFactory) MakeMany(count int, overrides ...func(*models.User)) []models.User {
+	users := make([]models.User, count)
+	for i := range users {
+		users[i] = f.Make(overrides...)
+	}
+	return users
+}
+
+func asciiSlug(value string) string {
+	return strings.ToLower(asciiReplacer.Replace(value))
+}
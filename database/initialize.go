@@ -1,6 +1,13 @@
 package database
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"zatrano/configs/envconfig"
 	"zatrano/configs/logconfig"
 	"zatrano/database/migrations"
 	"zatrano/database/seeders"
@@ -10,41 +17,35 @@ import (
 	"gorm.io/gorm"
 )
 
-func Initialize(db *gorm.DB, migrate bool, seed bool) {
-	if !migrate && !seed {
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+	MigrateRedo   = "redo"
+	MigratePlan   = "plan"
+)
+
+func Initialize(db *gorm.DB, migrateCommand string, steps int, seed string) {
+	if migrateCommand == "" && seed == "" {
 		logconfig.SLog.Info("Migrate veya seed bayrağı belirtilmedi, işlem yapılmayacak.")
 		return
 	}
 
-	tx := db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			logconfig.Log.Fatal("Veritabanı başlatma işlemi başarısız oldu, geri alındı (panic)", zap.Any("panic_info", r))
-		}
-		if tx.Error != nil && tx.Error != gorm.ErrInvalidTransaction {
-			logconfig.SLog.Warn("Başlatma sırasında hata oluştuğu için işlem geri alınıyor.")
-			tx.Rollback()
-		}
-	}()
-
 	logconfig.SLog.Info("Veritabanı başlatma işlemi başlıyor...")
 
-	if migrate {
-		logconfig.SLog.Info("Migrasyonlar çalıştırılıyor...")
-		if err := RunMigrationsInOrder(tx); err != nil {
-			tx.Rollback()
+	if migrateCommand != "" {
+		logconfig.SLog.Infof("Migrasyon komutu çalıştırılıyor: %s", migrateCommand)
+		if err := RunMigrations(db, migrateCommand, steps); err != nil {
 			logconfig.Log.Fatal("Migrasyon başarısız oldu", zap.Error(err))
 		}
-		logconfig.SLog.Info("Migrasyonlar tamamlandı.")
+		logconfig.SLog.Info("Migrasyon komutu tamamlandı.")
 	} else {
 		logconfig.SLog.Info("Migrate bayrağı belirtilmedi, migrasyon adımı atlanıyor.")
 	}
 
-	if seed {
-		logconfig.SLog.Info("Seeder'lar çalıştırılıyor...")
-		if err := CheckAndRunSeeders(tx); err != nil {
-			tx.Rollback()
+	if seed != "" {
+		logconfig.SLog.Infof("Seeder'lar çalıştırılıyor: %s", seed)
+		if err := RunSeeders(db, seed); err != nil {
 			logconfig.Log.Fatal("Seeding başarısız oldu", zap.Error(err))
 		}
 		logconfig.SLog.Info("Seeder'lar tamamlandı.")
@@ -52,47 +53,119 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 		logconfig.SLog.Info("Seed bayrağı belirtilmedi, seeder adımı atlanıyor.")
 	}
 
-	logconfig.SLog.Info("İşlem commit ediliyor...")
-	if err := tx.Commit().Error; err != nil {
-		logconfig.Log.Fatal("Commit başarısız oldu", zap.Error(err))
+	logconfig.SLog.Info("Veritabanı başlatma işlemi başarıyla tamamlandı")
+}
+
+// RunMigrations, verilen komutu çalıştırır; steps yalnızca down ve redo için kullanılır.
+func RunMigrations(db *gorm.DB, command string, steps int) error {
+	migrator := migrations.NewMigrator(db)
+
+	switch command {
+	case MigrateUp:
+		count, err := migrator.Up()
+		if err != nil {
+			return err
+		}
+		logconfig.SLog.Infof("%d migrasyon uygulandı.", count)
+	case MigrateDown:
+		if err := validateSteps(steps); err != nil {
+			return err
+		}
+		count, err := migrator.Down(steps)
+		if err != nil {
+			return err
+		}
+		logconfig.SLog.Infof("%d migrasyon geri alındı.", count)
+	case MigrateRedo:
+		if err := validateSteps(steps); err != nil {
+			return err
+		}
+		count, err := migrator.Redo(steps)
+		if err != nil {
+			return err
+		}
+		logconfig.SLog.Infof("%d migrasyon yeniden uygulandı.", count)
+	case MigrateStatus:
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(os.Stdout, statuses)
+	case MigratePlan:
+		return planMigrations(migrator)
+	default:
+		return fmt.Errorf("bilinmeyen migrasyon komutu: %q (up, down, status, redo, plan)", command)
 	}
+	return nil
+}
 
-	logconfig.SLog.Info("Veritabanı başlatma işlemi başarıyla tamamlandı")
+func validateSteps(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("geçersiz adım sayısı: %d", steps)
+	}
+	return nil
 }
 
-func RunMigrationsInOrder(db *gorm.DB) error {
-	if err := migrations.MigrateUsersTable(db); err != nil {
+// planMigrations, bekleyen migrasyonları ve modellerle canlı şema arasındaki farkı
+// kapatacak DDL'i hiçbir şey oluşturmadan yazdırır. Bekleyen migrasyon ya da fark varsa
+// hata döner; böylece komut sıfırdan farklı kodla çıkar ve dağıtımlar bu kontrole bağlanabilir.
+func planMigrations(migrator *migrations.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
 		return err
 	}
-	return nil
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			fmt.Fprintf(os.Stdout, "-- bekleyen migrasyon: %d_%s\n", status.Version, status.Name)
+			pending++
+		}
+	}
+
+	statements, err := migrator.Plan(append(models.All(), &migrations.SchemaMigration{}))
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		fmt.Fprintln(os.Stdout, statement+";")
+	}
+	if pending == 0 && len(statements) == 0 {
+		logconfig.SLog.Info("Şema modellerle uyumlu, uygulanacak değişiklik yok.")
+		return nil
+	}
+	return fmt.Errorf("%w: %d bekleyen migrasyon, %d ifade", migrations.ErrSchemaDrift, pending, len(statements))
 }
 
-func CheckAndRunSeeders(db *gorm.DB) error {
-	systemUser := seeders.GetSystemUserConfig()
-	var existingUser models.User
-	result := db.Where("email = ? AND type = ?", systemUser.Email, models.Dashboard).First(&existingUser)
-
-	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			logconfig.SLog.Info("Sistem kullanıcısı oluşturuluyor: %s (%s)...", systemUser.Name, systemUser.Email)
-			if err := seeders.SeedSystemUser(db); err != nil {
-				logconfig.Log.Error("Sistem kullanıcısı seed edilemedi", zap.Error(err))
-				return err
-			}
-			logconfig.SLog.Info(" -> Sistem kullanıcısı oluşturuldu.")
-		} else {
-			logconfig.Log.Error("Sistem kullanıcısı kontrol edilirken hata", zap.Error(result.Error))
-			return result.Error
+func printMigrationStatus(out io.Writer, statuses []migrations.MigrationStatus) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DURUM\tVERSİYON\tAD\tUYGULANMA ZAMANI")
+	for _, status := range statuses {
+		state := "bekliyor"
+		appliedAt := "-"
+		if status.Applied {
+			state = "uygulandı"
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
 		}
-	} else {
-		logconfig.SLog.Info("Sistem kullanıcısı '%s' (%s) zaten mevcut, oluşturma adımı atlanıyor.",
-			existingUser.Name, existingUser.Email)
-		logconfig.SLog.Info("Mevcut sistem kullanıcısı '%s' için güncelleme kontrolü yapılıyor...", existingUser.Email)
-		if err := seeders.SeedSystemUser(db); err != nil {
-			logconfig.Log.Error("Mevcut sistem kullanıcısı güncellenirken/kontrol edilirken hata", zap.Error(err))
-			return err
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", state, status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
+
+// RunSeeders, virgülle ayrılmış seeder adlarını ya da "all" değerini APP_ENV ortamına
+// göre çalıştırır.
+func RunSeeders(db *gorm.DB, seed string) error {
+	var names []string
+	for _, name := range strings.Split(seed, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
 		}
+	}
 
+	env := envconfig.GetEnvWithDefault("APP_ENV", seeders.EnvDevelopment)
+	count, err := seeders.NewRunner(db, env).Run(names)
+	if err != nil {
+		return err
 	}
+	logconfig.SLog.Infof("%d seeder çalıştırıldı (%s ortamı).", count, env)
 	return nil
 }
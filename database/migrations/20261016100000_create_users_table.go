@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016100000,
+		Name:    "create_users_table",
+		Up:      createUsersTableUp,
+		Down:    createUsersTableDown,
+	})
+}
+
+// userV20261016100000, migrasyonun yazıldığı andaki users tablosunun
+// şemasını sabitler; models.User sonradan değişse de bu migrasyon değişmez.
+type userV20261016100000 struct {
+	ID                uint `gorm:"primarykey"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	CreatedBy         uint
+	UpdatedBy         uint
+	DeletedBy         *uint  `gorm:"column:deleted_by"`
+	Name              string `gorm:"size:100;not null;index"`
+	Email             string `gorm:"size:100;unique;not null"`
+	Password          string `gorm:"size:255;not null"`
+	Status            bool   `gorm:"default:true;index"`
+	Type              string `gorm:"type:user_type;not null;default:'panel';index"`
+	ResetToken        string `gorm:"size:255;index"`
+	EmailVerified     bool   `gorm:"default:false;index"`
+	VerificationToken string `gorm:"size:255;index"`
+	Provider          string `gorm:"size:50;index"`
+	ProviderID        string `gorm:"size:100;index"`
+}
+
+func (userV20261016100000) TableName() string {
+	return "users"
+}
+
+func createUsersTableUp(tx *gorm.DB) error {
+	createEnum := `
+DO $$
+BEGIN
+	CREATE TYPE user_type AS ENUM ('dashboard', 'panel');
+EXCEPTION
+	WHEN duplicate_object THEN NULL;
+END
+$$;`
+	if err := tx.Exec(createEnum).Error; err != nil {
+		return err
+	}
+
+	// Migrasyon sistemi öncesinde AutoMigrate ile oluşturulmuş veritabanlarında
+	// tablo zaten mevcut olabilir; bu durumda sadece kayıt altına alınır.
+	if tx.Migrator().HasTable(&userV20261016100000{}) {
+		return nil
+	}
+	return tx.Migrator().CreateTable(&userV20261016100000{})
+}
+
+func createUsersTableDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropTable(&userV20261016100000{}); err != nil {
+		return err
+	}
+	return tx.Exec(`DROP TYPE IF EXISTS user_type;`).Error
+}
@@ -0,0 +1,146 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016110000,
+		Name:    "create_roles_and_permissions",
+		Up:      createRolesAndPermissionsUp,
+		Down:    createRolesAndPermissionsDown,
+	})
+}
+
+type permissionV20261016110000 struct {
+	ID          uint `gorm:"primarykey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	CreatedBy   uint
+	UpdatedBy   uint
+	DeletedBy   *uint  `gorm:"column:deleted_by"`
+	Name        string `gorm:"size:100;unique;not null"`
+	Description string `gorm:"size:255"`
+}
+
+func (permissionV20261016110000) TableName() string {
+	return "permissions"
+}
+
+type roleV20261016110000 struct {
+	ID          uint `gorm:"primarykey"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	CreatedBy   uint
+	UpdatedBy   uint
+	DeletedBy   *uint  `gorm:"column:deleted_by"`
+	Name        string `gorm:"size:100;unique;not null"`
+	Description string `gorm:"size:255"`
+}
+
+func (roleV20261016110000) TableName() string {
+	return "roles"
+}
+
+type rolePermissionV20261016110000 struct {
+	RoleID       uint `gorm:"primaryKey"`
+	PermissionID uint `gorm:"primaryKey;index"`
+}
+
+func (rolePermissionV20261016110000) TableName() string {
+	return "role_permissions"
+}
+
+type userRoleV20261016110000 struct {
+	UserID uint `gorm:"primaryKey"`
+	RoleID uint `gorm:"primaryKey;index"`
+}
+
+func (userRoleV20261016110000) TableName() string {
+	return "user_roles"
+}
+
+var defaultPermissionsV20261016110000 = []permissionV20261016110000{
+	{Name: "users.view", Description: "Kullanıcıları listeleme ve görüntüleme"},
+	{Name: "users.create", Description: "Kullanıcı oluşturma"},
+	{Name: "users.update", Description: "Kullanıcı güncelleme"},
+	{Name: "users.delete", Description: "Kullanıcı silme"},
+	{Name: "roles.manage", Description: "Rolleri ve yetkileri yönetme"},
+}
+
+func createRolesAndPermissionsUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(
+		&permissionV20261016110000{},
+		&roleV20261016110000{},
+	); err != nil {
+		return err
+	}
+
+	if err := tx.Migrator().CreateTable(
+		&rolePermissionV20261016110000{},
+		&userRoleV20261016110000{},
+	); err != nil {
+		return err
+	}
+
+	constraints := []string{
+		`ALTER TABLE role_permissions ADD CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE`,
+		`ALTER TABLE role_permissions ADD CONSTRAINT fk_role_permissions_permission FOREIGN KEY (permission_id) REFERENCES permissions(id) ON DELETE CASCADE`,
+		`ALTER TABLE user_roles ADD CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`,
+		`ALTER TABLE user_roles ADD CONSTRAINT fk_user_roles_role FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE`,
+	}
+	for _, statement := range constraints {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	permissions := make([]permissionV20261016110000, len(defaultPermissionsV20261016110000))
+	copy(permissions, defaultPermissionsV20261016110000)
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&permissions).Error; err != nil {
+		return err
+	}
+
+	roles := []roleV20261016110000{
+		{Name: "admin", Description: "Tüm yetkilere sahip yönetici"},
+		{Name: "support", Description: "Kullanıcıları görüntüleyebilen destek personeli"},
+	}
+	if err := tx.Create(&roles).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin'`).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'users.view'
+WHERE r.name = 'support'`).Error; err != nil {
+		return err
+	}
+
+	// Mevcut dashboard kullanıcılarının erişimi kesilmesin diye admin rolü atanır.
+	return tx.Exec(`
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u CROSS JOIN roles r
+WHERE r.name = 'admin' AND u.type = 'dashboard' AND u.deleted_at IS NULL`).Error
+}
+
+func createRolesAndPermissionsDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(
+		&userRoleV20261016110000{},
+		&rolePermissionV20261016110000{},
+		&roleV20261016110000{},
+		&permissionV20261016110000{},
+	)
+}
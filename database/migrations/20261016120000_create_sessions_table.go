@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016120000,
+		Name:    "create_sessions_table",
+		Up:      createSessionsTableUp,
+		Down:    createSessionsTableDown,
+	})
+}
+
+type sessionV20261016120000 struct {
+	Key       string     `gorm:"column:key;primaryKey;size:128"`
+	Data      []byte     `gorm:"column:data;not null"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;index:idx_sessions_expires_at"`
+}
+
+func (sessionV20261016120000) TableName() string {
+	return "sessions"
+}
+
+func createSessionsTableUp(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&sessionV20261016120000{})
+}
+
+func createSessionsTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&sessionV20261016120000{})
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016130000,
+		Name:    "create_user_sessions_table",
+		Up:      createUserSessionsTableUp,
+		Down:    createUserSessionsTableDown,
+	})
+}
+
+type userSessionV20261016130000 struct {
+	ID         uint   `gorm:"primarykey"`
+	SessionID  string `gorm:"size:128;uniqueIndex;not null"`
+	UserID     uint   `gorm:"index;not null"`
+	IPAddress  string `gorm:"size:45"`
+	UserAgent  string `gorm:"size:512"`
+	CreatedAt  time.Time
+	LastSeenAt time.Time `gorm:"index"`
+}
+
+func (userSessionV20261016130000) TableName() string {
+	return "user_sessions"
+}
+
+func createUserSessionsTableUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&userSessionV20261016130000{}); err != nil {
+		return err
+	}
+	return tx.Exec(`ALTER TABLE user_sessions ADD CONSTRAINT fk_user_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`).Error
+}
+
+func createUserSessionsTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&userSessionV20261016130000{})
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016140000,
+		Name:    "add_two_factor_authentication",
+		Up:      addTwoFactorAuthenticationUp,
+		Down:    addTwoFactorAuthenticationDown,
+	})
+}
+
+type userRecoveryCodeV20261016140000 struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    uint   `gorm:"not null;index:idx_user_recovery_codes_user_code,priority:1"`
+	CodeHash  string `gorm:"size:64;not null;index:idx_user_recovery_codes_user_code,priority:2"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (userRecoveryCodeV20261016140000) TableName() string {
+	return "user_recovery_codes"
+}
+
+func addTwoFactorAuthenticationUp(tx *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE users ADD COLUMN two_factor_secret VARCHAR(64)`,
+		`ALTER TABLE users ADD COLUMN two_factor_enabled BOOLEAN DEFAULT false`,
+		`ALTER TABLE users ADD COLUMN two_factor_required BOOLEAN DEFAULT false`,
+		`CREATE INDEX idx_users_two_factor_required ON users (two_factor_required)`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Migrator().CreateTable(&userRecoveryCodeV20261016140000{}); err != nil {
+		return err
+	}
+	return tx.Exec(`ALTER TABLE user_recovery_codes ADD CONSTRAINT fk_user_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`).Error
+}
+
+func addTwoFactorAuthenticationDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropTable(&userRecoveryCodeV20261016140000{}); err != nil {
+		return err
+	}
+	statements := []string{
+		`DROP INDEX IF EXISTS idx_users_two_factor_required`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS two_factor_required`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS two_factor_enabled`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS two_factor_secret`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
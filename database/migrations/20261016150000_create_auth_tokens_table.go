@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016150000,
+		Name:    "create_auth_tokens_table",
+		Up:      createAuthTokensTableUp,
+		Down:    createAuthTokensTableDown,
+	})
+}
+
+type authTokenV20261016150000 struct {
+	ID        uint      `gorm:"primarykey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	Purpose   string    `gorm:"size:32;not null;index"`
+	Payload   string    `gorm:"size:255"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (authTokenV20261016150000) TableName() string {
+	return "auth_tokens"
+}
+
+func createAuthTokensTableUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&authTokenV20261016150000{}); err != nil {
+		return err
+	}
+
+	// Eski düz metin tokenlar geçersiz sayılır; bekleyen bağlantılar yeniden istenmelidir.
+	statements := []string{
+		`ALTER TABLE auth_tokens ADD CONSTRAINT fk_auth_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS reset_token`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS verification_token`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createAuthTokensTableDown(tx *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE users ADD COLUMN reset_token VARCHAR(255)`,
+		`ALTER TABLE users ADD COLUMN verification_token VARCHAR(255)`,
+		`CREATE INDEX idx_users_reset_token ON users (reset_token)`,
+		`CREATE INDEX idx_users_verification_token ON users (verification_token)`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Migrator().DropTable(&authTokenV20261016150000{})
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016160000,
+		Name:    "create_login_attempts_table",
+		Up:      createLoginAttemptsTableUp,
+		Down:    createLoginAttemptsTableDown,
+	})
+}
+
+type loginAttemptV20261016160000 struct {
+	Key           string    `gorm:"column:key;primaryKey;size:255"`
+	Count         int       `gorm:"not null;default:0"`
+	LastAttemptAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+	ExpiresAt     time.Time `gorm:"not null;index"`
+}
+
+func (loginAttemptV20261016160000) TableName() string {
+	return "login_attempts"
+}
+
+func createLoginAttemptsTableUp(tx *gorm.DB) error {
+	return tx.Migrator().CreateTable(&loginAttemptV20261016160000{})
+}
+
+func createLoginAttemptsTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&loginAttemptV20261016160000{})
+}
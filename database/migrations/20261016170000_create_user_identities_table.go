@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016170000,
+		Name:    "create_user_identities_table",
+		Up:      createUserIdentitiesTableUp,
+		Down:    createUserIdentitiesTableDown,
+	})
+}
+
+type userIdentityV20261016170000 struct {
+	ID         uint   `gorm:"primarykey"`
+	UserID     uint   `gorm:"not null;index;uniqueIndex:idx_user_identities_user_provider"`
+	Provider   string `gorm:"size:50;not null;uniqueIndex:idx_user_identities_provider_subject;uniqueIndex:idx_user_identities_user_provider"`
+	Subject    string `gorm:"size:255;not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email      string `gorm:"size:100"`
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+func (userIdentityV20261016170000) TableName() string {
+	return "user_identities"
+}
+
+func createUserIdentitiesTableUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&userIdentityV20261016170000{}); err != nil {
+		return err
+	}
+
+	// users tablosundaki tekil provider alanları yeni tabloya taşınır.
+	statements := []string{
+		`ALTER TABLE user_identities ADD CONSTRAINT fk_user_identities_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`,
+		`INSERT INTO user_identities (user_id, provider, subject, email, created_at, last_used_at)
+			SELECT id, provider, provider_id, email, NOW(), NOW()
+			FROM users
+			WHERE provider IS NOT NULL AND provider <> '' AND provider_id IS NOT NULL AND provider_id <> ''`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS provider`,
+		`ALTER TABLE users DROP COLUMN IF EXISTS provider_id`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createUserIdentitiesTableDown(tx *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE users ADD COLUMN provider VARCHAR(50)`,
+		`ALTER TABLE users ADD COLUMN provider_id VARCHAR(100)`,
+		`CREATE INDEX idx_users_provider ON users (provider)`,
+		`CREATE INDEX idx_users_provider_id ON users (provider_id)`,
+		`UPDATE users SET provider = i.provider, provider_id = LEFT(i.subject, 100)
+			FROM (SELECT DISTINCT ON (user_id) user_id, provider, subject FROM user_identities ORDER BY user_id, created_at) i
+			WHERE users.id = i.user_id`,
+	}
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Migrator().DropTable(&userIdentityV20261016170000{})
+}
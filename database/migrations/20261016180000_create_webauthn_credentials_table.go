@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016180000,
+		Name:    "create_webauthn_credentials_table",
+		Up:      createWebAuthnCredentialsTableUp,
+		Down:    createWebAuthnCredentialsTableDown,
+	})
+}
+
+type webAuthnCredentialV20261016180000 struct {
+	ID              uint   `gorm:"primarykey"`
+	UserID          uint   `gorm:"not null;index"`
+	Name            string `gorm:"size:100;not null"`
+	CredentialID    []byte `gorm:"not null;uniqueIndex"`
+	PublicKey       []byte `gorm:"not null"`
+	AttestationType string `gorm:"size:32"`
+	Transports      string `gorm:"size:100"`
+	AAGUID          []byte
+	SignCount       uint32 `gorm:"not null;default:0"`
+	BackupEligible  bool   `gorm:"default:false"`
+	BackupState     bool   `gorm:"default:false"`
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time
+}
+
+func (webAuthnCredentialV20261016180000) TableName() string {
+	return "webauthn_credentials"
+}
+
+func createWebAuthnCredentialsTableUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&webAuthnCredentialV20261016180000{}); err != nil {
+		return err
+	}
+	return tx.Exec(`ALTER TABLE webauthn_credentials ADD CONSTRAINT fk_webauthn_credentials_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`).Error
+}
+
+func createWebAuthnCredentialsTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&webAuthnCredentialV20261016180000{})
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016190000,
+		Name:    "create_api_tokens_table",
+		Up:      createAPITokensTableUp,
+		Down:    createAPITokensTableDown,
+	})
+}
+
+type apiTokenV20261016190000 struct {
+	ID         uint      `gorm:"primarykey"`
+	UserID     uint      `gorm:"not null;index"`
+	Name       string    `gorm:"size:100;not null"`
+	TokenHash  string    `gorm:"size:64;not null;uniqueIndex"`
+	Prefix     string    `gorm:"size:16;not null"`
+	Scopes     string    `gorm:"size:500"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	LastUsedAt *time.Time
+	LastUsedIP string `gorm:"size:45"`
+	CreatedAt  time.Time
+}
+
+func (apiTokenV20261016190000) TableName() string {
+	return "api_tokens"
+}
+
+func createAPITokensTableUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&apiTokenV20261016190000{}); err != nil {
+		return err
+	}
+	return tx.Exec(`ALTER TABLE api_tokens ADD CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`).Error
+}
+
+func createAPITokensTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&apiTokenV20261016190000{})
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import "gorm.io/gorm"
+
+func init() {
+	register(Migration{
+		Version: 20261016200000,
+		Name:    "add_locale_to_users",
+		Up:      addLocaleToUsersUp,
+		Down:    addLocaleToUsersDown,
+	})
+}
+
+func addLocaleToUsersUp(tx *gorm.DB) error {
+	return tx.Exec(`ALTER TABLE users ADD COLUMN locale VARCHAR(10)`).Error
+}
+
+func addLocaleToUsersDown(tx *gorm.DB) error {
+	return tx.Exec(`ALTER TABLE users DROP COLUMN IF EXISTS locale`).Error
+}
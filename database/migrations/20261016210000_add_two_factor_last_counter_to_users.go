@@ -0,0 +1,20 @@
+package migrations
+
+import "gorm.io/gorm"
+
+func init() {
+	register(Migration{
+		Version: 20261016210000,
+		Name:    "add_two_factor_last_counter_to_users",
+		Up:      addTwoFactorLastCounterToUsersUp,
+		Down:    addTwoFactorLastCounterToUsersDown,
+	})
+}
+
+func addTwoFactorLastCounterToUsersUp(tx *gorm.DB) error {
+	return tx.Exec(`ALTER TABLE users ADD COLUMN two_factor_last_counter BIGINT NOT NULL DEFAULT 0`).Error
+}
+
+func addTwoFactorLastCounterToUsersDown(tx *gorm.DB) error {
+	return tx.Exec(`ALTER TABLE users DROP COLUMN IF EXISTS two_factor_last_counter`).Error
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016220000,
+		Name:    "create_seeder_runs_table",
+		Up:      createSeederRunsTableUp,
+		Down:    createSeederRunsTableDown,
+	})
+}
+
+type seederRunV20261016220000 struct {
+	Name  string    `gorm:"primaryKey;size:100"`
+	RanAt time.Time `gorm:"not null"`
+}
+
+func (seederRunV20261016220000) TableName() string {
+	return "seeder_runs"
+}
+
+// Tablo daha önce seeder komutu tarafından oluşturulmuş olabilir; bu durumda
+// mevcut kayıtlar korunur.
+func createSeederRunsTableUp(tx *gorm.DB) error {
+	if tx.Migrator().HasTable(&seederRunV20261016220000{}) {
+		return nil
+	}
+	return tx.Migrator().CreateTable(&seederRunV20261016220000{})
+}
+
+func createSeederRunsTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&seederRunV20261016220000{})
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import "gorm.io/gorm"
+
+func init() {
+	register(Migration{
+		Version: 20261016230000,
+		Name:    "add_manage_credentials_permission",
+		Up:      addManageCredentialsPermissionUp,
+		Down:    addManageCredentialsPermissionDown,
+	})
+}
+
+// users.manage_credentials yalnızca admin rolüne verilir; diğer rollerin bu yetkiyi
+// alması rol yönetiminden bilinçli olarak yapılmalıdır.
+func addManageCredentialsPermissionUp(tx *gorm.DB) error {
+	if err := tx.Exec(`
+INSERT INTO permissions (name, description, created_at, updated_at)
+VALUES ('users.manage_credentials', 'Kullanıcıların e-posta, şifre, tip ve iki adımlı doğrulama ayarlarını değiştirme', NOW(), NOW())
+ON CONFLICT (name) DO NOTHING`).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'users.manage_credentials'
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING`).Error
+}
+
+func addManageCredentialsPermissionDown(tx *gorm.DB) error {
+	return tx.Exec(`DELETE FROM permissions WHERE name = 'users.manage_credentials'`).Error
+}
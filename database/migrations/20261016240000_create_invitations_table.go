@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 20261016240000,
+		Name:    "create_invitations_table",
+		Up:      createInvitationsTableUp,
+		Down:    createInvitationsTableDown,
+	})
+}
+
+type invitationV20261016240000 struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	CreatedBy    uint
+	UpdatedBy    uint
+	DeletedBy    *uint     `gorm:"column:deleted_by"`
+	UserID       uint      `gorm:"not null;index"`
+	Category     string    `gorm:"size:100;not null;index"`
+	Title        string    `gorm:"size:200;not null"`
+	Description  string    `gorm:"type:text"`
+	EventDate    time.Time `gorm:"not null;index"`
+	Location     string    `gorm:"size:255"`
+	ContactPhone string    `gorm:"size:20"`
+	OnlineLink   string    `gorm:"size:500"`
+	Image        string    `gorm:"size:255"`
+}
+
+func (invitationV20261016240000) TableName() string {
+	return "invitations"
+}
+
+func createInvitationsTableUp(tx *gorm.DB) error {
+	if err := tx.Migrator().CreateTable(&invitationV20261016240000{}); err != nil {
+		return err
+	}
+	return tx.Exec(`ALTER TABLE invitations ADD CONSTRAINT fk_invitations_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE`).Error
+}
+
+func createInvitationsTableDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&invitationV20261016240000{})
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+	// DisableTransaction, ALTER TYPE ... ADD VALUE gibi transaction içinde
+	// çalıştırılamayan ifadeler içeren migrasyonlar için kullanılır.
+	DisableTransaction bool
+}
+
+type SchemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+var registry []Migration
+
+func register(m Migration) {
+	registry = append(registry, m)
+}
+
+func All() []Migration {
+	list := make([]Migration, len(registry))
+	copy(list, registry)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+	return list
+}
@@ -0,0 +1,235 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"zatrano/configs/logconfig"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateVersion = errors.New("aynı versiyona sahip birden fazla migrasyon kayıtlı")
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) *Migrator {
+	return &Migrator{db: db, migrations: All()}
+}
+
+func (m *Migrator) ensureSchemaTable() error {
+	if err := m.db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return fmt.Errorf("schema_migrations tablosu oluşturulamadı: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) validate() error {
+	seen := make(map[int64]string, len(m.migrations))
+	for _, migration := range m.migrations {
+		if name, ok := seen[migration.Version]; ok {
+			return fmt.Errorf("%w: %d (%s, %s)", ErrDuplicateVersion, migration.Version, name, migration.Name)
+		}
+		seen[migration.Version] = migration.Name
+	}
+	return nil
+}
+
+func (m *Migrator) appliedVersions() (map[int64]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if err := m.db.Order("version asc").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("uygulanmış migrasyonlar okunamadı: %w", err)
+	}
+	applied := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) prepare() (map[int64]SchemaMigration, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	if err := m.ensureSchemaTable(); err != nil {
+		return nil, err
+	}
+	return m.appliedVersions()
+}
+
+func (m *Migrator) run(migration Migration, fn func(tx *gorm.DB) error, record func(tx *gorm.DB) error) error {
+	if fn == nil {
+		return fmt.Errorf("migrasyon %d_%s için fonksiyon tanımlı değil", migration.Version, migration.Name)
+	}
+
+	if migration.DisableTransaction {
+		if err := fn(m.db); err != nil {
+			return err
+		}
+		return record(m.db)
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return record(tx)
+	})
+}
+
+func (m *Migrator) apply(migration Migration) error {
+	logconfig.SLog.Infof("Migrasyon uygulanıyor: %d_%s", migration.Version, migration.Name)
+	err := m.run(migration, migration.Up, func(tx *gorm.DB) error {
+		return tx.Create(&SchemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now().UTC(),
+		}).Error
+	})
+	if err != nil {
+		logconfig.Log.Error("Migrasyon uygulanamadı",
+			zap.Int64("version", migration.Version),
+			zap.String("name", migration.Name),
+			zap.Error(err),
+		)
+		return fmt.Errorf("migrasyon %d_%s uygulanamadı: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) revert(migration Migration) error {
+	logconfig.SLog.Infof("Migrasyon geri alınıyor: %d_%s", migration.Version, migration.Name)
+	err := m.run(migration, migration.Down, func(tx *gorm.DB) error {
+		return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+	})
+	if err != nil {
+		logconfig.Log.Error("Migrasyon geri alınamadı",
+			zap.Int64("version", migration.Version),
+			zap.String("name", migration.Name),
+			zap.Error(err),
+		)
+		return fmt.Errorf("migrasyon %d_%s geri alınamadı: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// Up bekleyen tüm migrasyonları versiyon sırasıyla uygular ve uygulanan migrasyon sayısını döner.
+func (m *Migrator) Up() (int, error) {
+	applied, err := m.prepare()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Down en son uygulanan steps adet migrasyonu ters sırayla geri alır.
+func (m *Migrator) Down(steps int) (int, error) {
+	applied, err := m.prepare()
+	if err != nil {
+		return 0, err
+	}
+	if steps <= 0 {
+		steps = 1
+	}
+
+	count := 0
+	for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.revert(migration); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Redo en son uygulanan steps adet migrasyonu geri alıp yeniden uygular. Geri alma
+// yarıda kalırsa o ana kadar geri alınanlar yeniden uygulanır; dönen sayı yeniden
+// uygulanan migrasyon sayısıdır.
+func (m *Migrator) Redo(steps int) (int, error) {
+	applied, err := m.prepare()
+	if err != nil {
+		return 0, err
+	}
+	if steps <= 0 {
+		steps = 1
+	}
+
+	var reverted []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.revert(migration); err != nil {
+			count, applyErr := m.reapply(reverted)
+			return count, errors.Join(err, applyErr)
+		}
+		reverted = append(reverted, migration)
+	}
+
+	return m.reapply(reverted)
+}
+
+// reapply, ters sırayla geri alınmış migrasyonları versiyon sırasıyla yeniden uygular.
+func (m *Migrator) reapply(reverted []Migration) (int, error) {
+	for i := len(reverted) - 1; i >= 0; i-- {
+		if err := m.apply(reverted[i]); err != nil {
+			return len(reverted) - 1 - i, err
+		}
+	}
+	return len(reverted), nil
+}
+
+// Status, migrasyonların durumunu salt okunur olarak döner; schema_migrations tablosu
+// henüz yoksa oluşturmaz, tüm migrasyonları bekliyor olarak raporlar.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	applied := map[int64]SchemaMigration{}
+	if m.db.Migrator().HasTable(&SchemaMigration{}) {
+		var err error
+		if applied, err = m.appliedVersions(); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var ErrSchemaDrift = errors.New("modeller ile veritabanı şeması arasında fark var")
+
+// enumType, Postgres enum tipine karşılık gelen model alanlarıdır (ör. models.UserType).
+type enumType interface {
+	GormDataType() string
+	EnumValues() []string
+}
+
+// Plan, modelleri canlı şemayla karşılaştırır ve şemayı modellere uydurmak için
+// çalıştırılacak DDL ifadelerini döner; hiçbir ifade uygulanmaz. Tablo, kolon, indeks ve
+// kısıtlar GORM'un AutoMigrate mantığıyla hesaplanır: şema sorguları gerçekten çalışır,
+// DDL ise çalıştırılmak yerine kaydedilir. Enum tipleri ayrıca pg_enum ile karşılaştırılır.
+func (m *Migrator) Plan(models []interface{}) ([]string, error) {
+	statements, err := m.planEnums(models)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &recordingPool{ConnPool: m.db.ConnPool, dialector: m.db.Dialector}
+	tx := m.db.Session(&gorm.Session{NewDB: true, Logger: logger.Discard})
+	tx.Statement.ConnPool = pool
+	if err := tx.Migrator().AutoMigrate(models...); err != nil {
+		return nil, fmt.Errorf("şema karşılaştırılamadı: %w", err)
+	}
+
+	return append(statements, pool.statements...), nil
+}
+
+func (m *Migrator) planEnums(models []interface{}) ([]string, error) {
+	var (
+		statements []string
+		seen       = make(map[string]bool)
+	)
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: m.db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("model çözümlenemedi: %w", err)
+		}
+
+		for _, field := range stmt.Schema.Fields {
+			enum, ok := reflect.New(field.FieldType).Interface().(enumType)
+			if !ok || seen[enum.GormDataType()] {
+				continue
+			}
+			name := enum.GormDataType()
+			seen[name] = true
+
+			var existing []string
+			err := m.db.Raw(`SELECT e.enumlabel FROM pg_type t JOIN pg_enum e ON e.enumtypid = t.oid
+WHERE t.typname = ? ORDER BY e.enumsortorder`, name).Scan(&existing).Error
+			if err != nil {
+				return nil, fmt.Errorf("%s enum değerleri okunamadı: %w", name, err)
+			}
+
+			if len(existing) == 0 {
+				statements = append(statements, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", name, quoteLiterals(enum.EnumValues())))
+				continue
+			}
+			present := make(map[string]bool, len(existing))
+			for _, value := range existing {
+				present[value] = true
+			}
+			for _, value := range enum.EnumValues() {
+				if !present[value] {
+					statements = append(statements, fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", name, quoteLiterals([]string{value})))
+				}
+			}
+		}
+	}
+	return statements, nil
+}
+
+func quoteLiterals(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// recordingPool, sorguları gerçek bağlantıya iletir; Exec ile gelen DDL'i ise
+// çalıştırmadan kaydeder.
+type recordingPool struct {
+	gorm.ConnPool
+	dialector  gorm.Dialector
+	statements []string
+}
+
+func (p *recordingPool) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	p.statements = append(p.statements, p.dialector.Explain(query, args...))
+	return driver.RowsAffected(0), nil
+}
@@ -0,0 +1,41 @@
+package seeders
+
+import (
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/database/factories"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Seeder{
+		Name:         "fake_invitations",
+		Dependencies: []string{"fake_users"},
+		Environments: []string{EnvDevelopment},
+		Run:          seedFakeInvitations,
+	})
+}
+
+// seedFakeInvitations, panel kullanıcılarının her birine en fazla
+// SEED_FAKE_INVITATIONS_PER_USER kadar sahte davetiye ekler.
+func seedFakeInvitations(tx *gorm.DB) error {
+	var userIDs []uint
+	if err := tx.Model(&models.User{}).Where("type = ?", models.Panel).Order("id").Pluck("id", &userIDs).Error; err != nil {
+		return err
+	}
+
+	factory := factories.NewInvitationFactory(time.Now().UnixNano())
+	invitations := factory.MakeMany(userIDs, envconfig.GetEnvAsInt("SEED_FAKE_INVITATIONS_PER_USER", 3))
+	if len(invitations) > 0 {
+		if err := tx.CreateInBatches(&invitations, 100).Error; err != nil {
+			return err
+		}
+	}
+
+	logconfig.SLog.Infof("%d sahte davetiye oluşturuldu.", len(invitations))
+	return nil
+}
@@ -0,0 +1,80 @@
+package seeders
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/database/factories"
+	"zatrano/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func init() {
+	register(Seeder{
+		Name:         "fake_users",
+		Dependencies: []string{"system_user"},
+		Environments: []string{EnvDevelopment},
+		Run:          seedFakeUsers,
+	})
+}
+
+// seedFakeUsers, SEED_FAKE_USER_COUNT kadar panel kullanıcısı oluşturur. Şifreleri
+// SEED_FAKE_USER_PASSWORD'dür; tanımlı değilse rastgele bir şifre kullanılır ve
+// kullanıcılarla giriş yapılamaz.
+func seedFakeUsers(tx *gorm.DB) error {
+	password := os.Getenv("SEED_FAKE_USER_PASSWORD")
+	if password == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return err
+		}
+		password = hex.EncodeToString(buf)
+		logconfig.SLog.Warn("SEED_FAKE_USER_PASSWORD tanımlı değil, sahte kullanıcılar rastgele bir şifreyle oluşturuluyor.")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	factory := factories.NewUserFactory(time.Now().UnixNano(), string(hashedPassword))
+	users := factory.MakeMany(envconfig.GetEnvAsInt("SEED_FAKE_USER_COUNT", 25))
+
+	// Status alanı default:true olduğundan GORM false değerini INSERT'e yazmaz; pasif
+	// kullanıcılar tek tek eklenip ardından ayrıca pasifleştirilir.
+	var active, inactive []models.User
+	for _, user := range users {
+		if user.Status {
+			active = append(active, user)
+		} else {
+			inactive = append(inactive, user)
+		}
+	}
+	if len(active) > 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit("Roles").CreateInBatches(&active, 100).Error; err != nil {
+			return err
+		}
+	}
+	for i := range inactive {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit("Roles").Create(&inactive[i])
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		if err := tx.Model(&inactive[i]).Update("status", false).Error; err != nil {
+			return err
+		}
+	}
+
+	logconfig.SLog.Infof("%d sahte kullanıcı oluşturuldu.", len(users))
+	return nil
+}
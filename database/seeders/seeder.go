@@ -0,0 +1,178 @@
+package seeders
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	SeedAll = "all"
+
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+var (
+	ErrUnknownSeeder         = errors.New("bilinmeyen seeder")
+	ErrDuplicateSeeder       = errors.New("aynı ada sahip birden fazla seeder kayıtlı")
+	ErrDependencyCycle       = errors.New("seeder bağımlılıklarında döngü var")
+	ErrEnvironmentNotAllowed = errors.New("seeder bu ortamda çalıştırılamaz")
+	ErrSeederRunsMissing     = errors.New("seeder_runs tablosu yok, önce migrasyonları çalıştırın")
+)
+
+type Seeder struct {
+	Name         string
+	Dependencies []string
+	// Environments boşsa seeder her ortamda çalışabilir.
+	Environments []string
+	Run          func(tx *gorm.DB) error
+}
+
+func (s Seeder) allowedIn(env string) bool {
+	if len(s.Environments) == 0 {
+		return true
+	}
+	for _, allowed := range s.Environments {
+		if allowed == env {
+			return true
+		}
+	}
+	return false
+}
+
+var registry = map[string]Seeder{}
+
+func register(s Seeder) {
+	if _, exists := registry[s.Name]; exists {
+		panic(fmt.Sprintf("%v: %s", ErrDuplicateSeeder, s.Name))
+	}
+	registry[s.Name] = s
+}
+
+// Names, kayıtlı seeder adlarını alfabetik sırayla döner.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type Runner struct {
+	db  *gorm.DB
+	env string
+}
+
+func NewRunner(db *gorm.DB, env string) *Runner {
+	return &Runner{db: db, env: env}
+}
+
+// Run, istenen seeder'ları bağımlılıklarından sonra gelecek şekilde sırayla çalıştırır.
+// "all", bulunulan ortamda çalışabilen tüm seeder'ları seçer. seeder_runs tablosunda
+// kaydı olan seeder'lar atlanır; her seeder kaydıyla birlikte tek transaction'da çalışır.
+func (r *Runner) Run(names []string) (int, error) {
+	plan, err := r.plan(names)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.db.Migrator().HasTable(&models.SeederRun{}) {
+		return 0, ErrSeederRunsMissing
+	}
+	var rows []models.SeederRun
+	if err := r.db.Find(&rows).Error; err != nil {
+		return 0, fmt.Errorf("çalışmış seeder'lar okunamadı: %w", err)
+	}
+	ran := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		ran[row.Name] = true
+	}
+
+	count := 0
+	for _, seeder := range plan {
+		if ran[seeder.Name] {
+			logconfig.SLog.Infof("Seeder '%s' daha önce çalıştırılmış, atlanıyor.", seeder.Name)
+			continue
+		}
+
+		logconfig.SLog.Infof("Seeder çalıştırılıyor: %s", seeder.Name)
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			if err := seeder.Run(tx); err != nil {
+				return err
+			}
+			return tx.Create(&models.SeederRun{Name: seeder.Name, RanAt: time.Now()}).Error
+		})
+		if err != nil {
+			logconfig.Log.Error("Seeder başarısız oldu, işlem geri alındı", zap.String("seeder", seeder.Name), zap.Error(err))
+			return count, fmt.Errorf("seeder %s: %w", seeder.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *Runner) plan(names []string) ([]Seeder, error) {
+	var requested []string
+	for _, name := range names {
+		if name != SeedAll {
+			requested = append(requested, name)
+			continue
+		}
+		for _, registered := range Names() {
+			if registry[registered].allowedIn(r.env) {
+				requested = append(requested, registered)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(registry))
+	var plan []Seeder
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		seeder, ok := registry[name]
+		if !ok {
+			return fmt.Errorf("%w: %q (kayıtlı: %s)", ErrUnknownSeeder, name, strings.Join(Names(), ", "))
+		}
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append(path, name), " -> "))
+		}
+		if !seeder.allowedIn(r.env) {
+			return fmt.Errorf("%w: %s (%s ortamı, izin verilen: %s)", ErrEnvironmentNotAllowed, name, r.env, strings.Join(seeder.Environments, ", "))
+		}
+
+		state[name] = visiting
+		for _, dependency := range seeder.Dependencies {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		plan = append(plan, seeder)
+		return nil
+	}
+
+	for _, name := range requested {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
@@ -1,8 +1,15 @@
 package seeders
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
 
+	"zatrano/configs/envconfig"
 	"zatrano/configs/logconfig"
 	"zatrano/models"
 
@@ -11,85 +18,111 @@ import (
 	"gorm.io/gorm"
 )
 
-func GetSystemUserConfig() models.User {
-	return models.User{
-		Name:     "zatrano",
-		Email:    "zatrano@zatrano",
-		Type:     models.Dashboard,
-		Password: "zatrano",
-	}
+const systemUserMinPasswordLength = 8
+
+var ErrSystemUserCredentials = errors.New("sistem kullanıcısı bilgileri eksik: SYSTEM_USER_EMAIL ve SYSTEM_USER_PASSWORD tanımlayın ya da komutu terminalden çalıştırın")
+
+func init() {
+	register(Seeder{
+		Name: "system_user",
+		Run:  SeedSystemUser,
+	})
 }
 
-func SeedSystemUser(db *gorm.DB) error {
-	systemUserConfig := GetSystemUserConfig()
+// SystemUserCredentials, sistem kullanıcısının bilgileri. Değerler kaynak kodda tutulmaz;
+// SYSTEM_USER_* ortam değişkenlerinden ya da terminalden sorularak alınır.
+type SystemUserCredentials struct {
+	Name     string
+	Email    string
+	Password string
+}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(systemUserConfig.Password), bcrypt.DefaultCost)
-	if err != nil {
-		logconfig.Log.Error("Sistem kullanıcısının şifresi hash'lenirken hata oluştu",
-			zap.String("email", systemUserConfig.Email),
-			zap.Error(err),
-		)
-		return err
+func systemUserFromEnv() SystemUserCredentials {
+	return SystemUserCredentials{
+		Name:     envconfig.GetEnvWithDefault("SYSTEM_USER_NAME", "Sistem Yöneticisi"),
+		Email:    strings.TrimSpace(os.Getenv("SYSTEM_USER_EMAIL")),
+		Password: os.Getenv("SYSTEM_USER_PASSWORD"),
 	}
+}
 
-	userToSeed := models.User{
-		Name:          systemUserConfig.Name,
-		Email:         systemUserConfig.Email,
-		Type:          systemUserConfig.Type,
-		Password:      string(hashedPassword),
-		Status:        true,
-		EmailVerified: true,
+func SeedSystemUser(db *gorm.DB) error {
+	prompt := newPrompter()
+	credentials := systemUserFromEnv()
+	if credentials.Email == "" {
+		if !prompt.interactive() {
+			return ErrSystemUserCredentials
+		}
+		credentials.Email = prompt.ask("Sistem kullanıcısı e-posta adresi: ")
+		if credentials.Email == "" {
+			return ErrSystemUserCredentials
+		}
 	}
 
 	var existingUser models.User
-	result := db.Where("email = ? AND type = ?", userToSeed.Email, userToSeed.Type).First(&existingUser)
+	result := db.Where("email = ? AND type = ?", credentials.Email, models.Dashboard).First(&existingUser)
 
 	if result.Error == nil {
-		logconfig.SLog.Info("Sistem kullanıcısı '%s' zaten mevcut. Güncelleme gerekip gerekmediği kontrol ediliyor...", userToSeed.Email)
+		logconfig.SLog.Infof("Sistem kullanıcısı '%s' zaten mevcut. Güncelleme gerekip gerekmediği kontrol ediliyor...", credentials.Email)
 
 		updateFields := make(map[string]interface{})
-		needsUpdate := false
-
-		if existingUser.Name != userToSeed.Name {
-			updateFields["name"] = userToSeed.Name
-			needsUpdate = true
+		if existingUser.Name != credentials.Name {
+			updateFields["name"] = credentials.Name
 		}
 		if !existingUser.Status {
 			updateFields["status"] = true
-			needsUpdate = true
 		}
 
-		if needsUpdate {
-			logconfig.SLog.Info("Mevcut sistem kullanıcısı '%s' güncelleniyor...", userToSeed.Email)
-
-			ctx := context.WithValue(context.Background(), "user_id", uint(1))
-			err := db.WithContext(ctx).Model(&existingUser).Updates(updateFields).Error
-			if err != nil {
+		if len(updateFields) > 0 {
+			ctx := context.WithValue(context.Background(), "user_id", existingUser.ID)
+			if err := db.WithContext(ctx).Model(&existingUser).Updates(updateFields).Error; err != nil {
 				logconfig.Log.Error("Mevcut sistem kullanıcısı güncellenemedi",
-					zap.String("email", userToSeed.Email),
+					zap.String("email", credentials.Email),
 					zap.Error(err),
 				)
 				return err
 			}
-			logconfig.SLog.Info("Mevcut sistem kullanıcısı '%s' başarıyla güncellendi.", userToSeed.Email)
-		} else {
-			logconfig.SLog.Info("Mevcut sistem kullanıcısı '%s' için güncelleme gerekmiyor.", userToSeed.Email)
+			logconfig.SLog.Infof("Mevcut sistem kullanıcısı '%s' başarıyla güncellendi.", credentials.Email)
 		}
-		return nil
+		return assignAdminRole(db, &existingUser)
 
-	} else if result.Error != gorm.ErrRecordNotFound {
+	} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		logconfig.Log.Error("Sistem kullanıcısı kontrol edilirken veritabanı hatası",
-			zap.String("email", userToSeed.Email),
+			zap.String("email", credentials.Email),
 			zap.Error(result.Error),
 		)
 		return result.Error
 	}
 
-	logconfig.SLog.Info("Sistem kullanıcısı '%s' bulunamadı. Oluşturuluyor...", userToSeed.Email)
+	if credentials.Password == "" {
+		if !prompt.interactive() {
+			return ErrSystemUserCredentials
+		}
+		credentials.Password = prompt.askSecret("Sistem kullanıcısı şifresi: ")
+	}
+	if len(credentials.Password) < systemUserMinPasswordLength {
+		return fmt.Errorf("sistem kullanıcısı şifresi en az %d karakter olmalıdır", systemUserMinPasswordLength)
+	}
 
-	ctx := context.WithValue(context.Background(), "user_id", uint(1))
-	err = db.WithContext(ctx).Create(&userToSeed).Error
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
 	if err != nil {
+		logconfig.Log.Error("Sistem kullanıcısının şifresi hash'lenirken hata oluştu",
+			zap.String("email", credentials.Email),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	userToSeed := models.User{
+		Name:          credentials.Name,
+		Email:         credentials.Email,
+		Type:          models.Dashboard,
+		Password:      string(hashedPassword),
+		Status:        true,
+		EmailVerified: true,
+	}
+
+	logconfig.SLog.Infof("Sistem kullanıcısı '%s' bulunamadı. Oluşturuluyor...", userToSeed.Email)
+	if err := db.Create(&userToSeed).Error; err != nil {
 		logconfig.Log.Error("Sistem kullanıcısı oluşturulamadı",
 			zap.String("email", userToSeed.Email),
 			zap.Error(err),
@@ -97,6 +130,62 @@ func SeedSystemUser(db *gorm.DB) error {
 		return err
 	}
 
-	logconfig.SLog.Info("Sistem kullanıcısı '%s' başarıyla oluşturuldu.", userToSeed.Email)
+	logconfig.SLog.Infof("Sistem kullanıcısı '%s' başarıyla oluşturuldu.", userToSeed.Email)
+	return assignAdminRole(db, &userToSeed)
+}
+
+func assignAdminRole(db *gorm.DB, user *models.User) error {
+	var role models.Role
+	if err := db.Where("name = ?", models.RoleAdmin).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logconfig.SLog.Warn("Admin rolü bulunamadı, sistem kullanıcısına rol atanmadı. Migrasyonların çalıştırıldığından emin olun.")
+			return nil
+		}
+		return err
+	}
+
+	if err := db.Model(user).Omit("Roles.*").Association("Roles").Append(&role); err != nil {
+		logconfig.Log.Error("Sistem kullanıcısına admin rolü atanamadı",
+			zap.String("email", user.Email),
+			zap.Error(err),
+		)
+		return err
+	}
 	return nil
 }
+
+type prompter struct {
+	reader *bufio.Reader
+}
+
+func newPrompter() *prompter {
+	return &prompter{reader: bufio.NewReader(os.Stdin)}
+}
+
+// interactive, standart girdi bir terminale bağlıysa true döner; CI ve pipe ile
+// çalıştırmalarda soru sorulmaz.
+func (p *prompter) interactive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (p *prompter) ask(question string) string {
+	fmt.Fprint(os.Stderr, question)
+	line, _ := p.reader.ReadString('\n')
+	return strings.TrimSpace(line)
+}
+
+// askSecret, yazılanları ekrana yansıtmadan okur. stty bulunamazsa girdi görünür kalır.
+func (p *prompter) askSecret(question string) string {
+	echoOff := exec.Command("stty", "-echo")
+	echoOff.Stdin = os.Stdin
+	if err := echoOff.Run(); err == nil {
+		defer func() {
+			echoOn := exec.Command("stty", "echo")
+			echoOn.Stdin = os.Stdin
+			_ = echoOn.Run()
+			fmt.Fprintln(os.Stderr)
+		}()
+	}
+	return p.ask(question)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"zatrano/models"
+	"zatrano/pkg/openapi"
+	"zatrano/pkg/queryparams"
+	"zatrano/requests"
+)
+
+// Spec, /api/v1 uç noktalarının OpenAPI belgesidir. routes/api.go'ya eklenen
+// her uç nokta burada da tanımlanmalıdır; `go run ./cmd/openapi` çıktısı
+// docs/openapi.json ile karşılaştırılarak incelenir.
+func Spec() *openapi.Document {
+	doc := openapi.New("zatrano API", "1.0.0",
+		"Kişisel API tokenı ile erişilen JSON uç noktaları. Hatalar RFC 7807 problem+json olarak döner.")
+	doc.AddTag("users", "Kullanıcı yönetimi")
+
+	doc.Add(openapi.Endpoint{
+		ID:          "listUsers",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/users",
+		Summary:     "Kullanıcıları listeler",
+		Description: "Ek filtreler filter[alan][operatör]=değer biçimindedir, ör. filter[type][in]=dashboard,panel veya filter[created_at][date-between]=2026-01-01,2026-01-31. Operatörler: eq, in, range, like, date-between, is-null. Filtrelenemeyen alan, desteklenmeyen operatör ya da alan tipine uymayan değer (ör. id için sayı olmayan, status için true/false dışında bir değer) 400 döner. pagination=cursor ile yanıtın meta alanı sayfa numaraları yerine per_page, next, prev ve isteğe bağlı total_items içerir.",
+		Tag:         "users",
+		Permission:  models.PermissionUsersView,
+		Query:       queryparams.ListParams{},
+		Responses:   map[int]any{http.StatusOK: openapi.ListOf(queryparams.PaginatedResult{}, UserResource{})},
+		Errors:      []int{http.StatusBadRequest},
+	})
+	doc.Add(openapi.Endpoint{
+		ID:          "createUser",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/users",
+		Summary:     "Kullanıcı oluşturur",
+		Description: "role_ids göndermek roles.manage yetkisi ister; yoksa 403 döner.",
+		Tag:         "users",
+		Permission:  models.PermissionUsersCreate,
+		Body:        requests.APIUserCreateRequest{},
+		Responses:   map[int]any{http.StatusCreated: openapi.DataOf(UserResource{})},
+		Errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+	})
+	doc.Add(openapi.Endpoint{
+		ID:         "showUser",
+		Method:     http.MethodGet,
+		Path:       "/api/v1/users/:id",
+		Summary:    "Kullanıcı ayrıntısını döner",
+		Tag:        "users",
+		Permission: models.PermissionUsersView,
+		Responses:  map[int]any{http.StatusOK: openapi.DataOf(UserResource{})},
+		Errors:     []int{http.StatusNotFound},
+	})
+	doc.Add(openapi.Endpoint{
+		ID:          "updateUser",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/users/:id",
+		Summary:     "Kullanıcıyı günceller",
+		Description: "Gönderilmeyen status, two_factor_required ve role_ids alanları mevcut değerlerini korur. role_ids göndermek roles.manage yetkisi ister; yoksa 403 döner. email, password, type veya two_factor_required değişikliği users.manage_credentials ister. Sistem kullanıcısı ve işlemi yapanın sahip olmadığı yetkilere sahip kullanıcılar güncellenemez (403).",
+		Tag:         "users",
+		Permission:  models.PermissionUsersUpdate,
+		Body:        requests.APIUserUpdateRequest{},
+		Responses:   map[int]any{http.StatusOK: openapi.DataOf(UserResource{})},
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+	})
+	doc.Add(openapi.Endpoint{
+		ID:         "deleteUser",
+		Method:     http.MethodDelete,
+		Path:       "/api/v1/users/:id",
+		Summary:    "Kullanıcıyı siler",
+		Tag:        "users",
+		Permission: models.PermissionUsersDelete,
+		Responses:  map[int]any{http.StatusNoContent: nil},
+		Errors:     []int{http.StatusForbidden, http.StatusNotFound},
+	})
+
+	return doc
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/queryparams"
+	"zatrano/requests"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	errInvalidQuery            = apperrors.BadRequest("invalid_query", "Geçersiz sorgu parametreleri.")
+	errRoleAssignmentForbidden = apperrors.Forbidden("role_assignment_forbidden", "Rol atamak için roles.manage yetkisi gerekir.")
+)
+
+type UserHandler struct {
+	userService services.IUserService
+}
+
+func NewUserHandler() *UserHandler {
+	return &UserHandler{userService: services.NewUserService()}
+}
+
+func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
+	var params queryparams.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		return errInvalidQuery.Wrap(err)
+	}
+	params.Filters = queryparams.ParseFilters(c.Queries())
+	params.Normalize()
+
+	if params.Pagination == queryparams.PaginationCursor {
+		result, err := h.userService.GetUsersByCursor(params)
+		if err != nil {
+			return err
+		}
+		if users, ok := result.Data.([]models.User); ok {
+			result.Data = newUserResources(users)
+		}
+		return c.JSON(result)
+	}
+
+	result, err := h.userService.GetAllUsers(params)
+	if err != nil {
+		return err
+	}
+	if users, ok := result.Data.([]models.User); ok {
+		result.Data = newUserResources(users)
+	}
+	return c.JSON(result)
+}
+
+func (h *UserHandler) ShowUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return services.ErrUserNotFound
+	}
+
+	user, err := h.userService.GetUserByID(uint(id))
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": newUserResource(user)})
+}
+
+func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
+	req, ok := requests.Get[requests.APIUserCreateRequest](c)
+	if !ok {
+		return apperrors.ErrBadRequest
+	}
+	if len(req.RoleIDs) > 0 && !auth.Can(c, models.PermissionRolesManage) {
+		return errRoleAssignmentForbidden
+	}
+
+	user := &models.User{
+		Name:              req.Name,
+		Email:             req.Email,
+		Password:          req.Password,
+		Status:            req.Status == nil || *req.Status,
+		Type:              models.UserType(req.Type),
+		TwoFactorRequired: req.TwoFactorRequired,
+	}
+	if err := h.userService.CreateUser(c.UserContext(), user); err != nil {
+		logconfig.Log.Warn("API: Kullanıcı oluşturulamadı", zap.String("email", req.Email), zap.Error(err))
+		return err
+	}
+
+	if len(req.RoleIDs) > 0 {
+		if err := h.userService.SyncUserRoles(c.UserContext(), user.ID, req.RoleIDs); err != nil {
+			return err
+		}
+	}
+
+	created, err := h.userService.GetUserByID(user.ID)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"data": newUserResource(created)})
+}
+
+func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return services.ErrUserNotFound
+	}
+	userID := uint(id)
+	req, ok := requests.Get[requests.APIUserUpdateRequest](c)
+	if !ok {
+		return apperrors.ErrBadRequest
+	}
+
+	if req.RoleIDs != nil && !auth.Can(c, models.PermissionRolesManage) {
+		return errRoleAssignmentForbidden
+	}
+
+	existing, err := h.userService.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	// Gönderilmeyen bayraklar mevcut değerlerini korur.
+	userData := &models.User{
+		Name:              req.Name,
+		Email:             req.Email,
+		Password:          req.Password,
+		Type:              models.UserType(req.Type),
+		Status:            existing.Status,
+		TwoFactorRequired: existing.TwoFactorRequired,
+	}
+	if req.Status != nil {
+		userData.Status = *req.Status
+	}
+	if req.TwoFactorRequired != nil {
+		userData.TwoFactorRequired = *req.TwoFactorRequired
+	}
+
+	if err := h.userService.UpdateUser(c.UserContext(), userID, userData); err != nil {
+		logconfig.Log.Warn("API: Kullanıcı güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+
+	if req.RoleIDs != nil {
+		if err := h.userService.SyncUserRoles(c.UserContext(), userID, *req.RoleIDs); err != nil {
+			return err
+		}
+	}
+
+	updated, err := h.userService.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"data": newUserResource(updated)})
+}
+
+func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return services.ErrUserNotFound
+	}
+
+	if err := h.userService.DeleteUser(c.UserContext(), uint(id)); err != nil {
+		logconfig.Log.Warn("API: Kullanıcı silinemedi", zap.Int("user_id", id), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
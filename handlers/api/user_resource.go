@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"time"
+
+	"zatrano/models"
+)
+
+// UserResource, API yanıtlarında kullanıcıların dışarıya açılan halidir;
+// şifre ve iki adımlı doğrulama sırrı gibi alanlar hiçbir zaman dönmez.
+type UserResource struct {
+	ID                uint      `json:"id"`
+	Name              string    `json:"name"`
+	Email             string    `json:"email"`
+	Type              string    `json:"type"`
+	Status            bool      `json:"status"`
+	EmailVerified     bool      `json:"email_verified"`
+	TwoFactorEnabled  bool      `json:"two_factor_enabled"`
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	Roles             []string  `json:"roles"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+func newUserResource(user *models.User) UserResource {
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+	return UserResource{
+		ID:                user.ID,
+		Name:              user.Name,
+		Email:             user.Email,
+		Type:              string(user.Type),
+		Status:            user.Status,
+		EmailVerified:     user.EmailVerified,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
+		TwoFactorRequired: user.TwoFactorRequired,
+		Roles:             roles,
+		CreatedAt:         user.CreatedAt,
+		UpdatedAt:         user.UpdatedAt,
+	}
+}
+
+func newUserResources(users []models.User) []UserResource {
+	resources := make([]UserResource, 0, len(users))
+	for i := range users {
+		resources = append(resources, newUserResource(&users[i]))
+	}
+	return resources
+}
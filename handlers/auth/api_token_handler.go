@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *AuthHandler) CreateAPIToken(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+
+	var req struct {
+		Name      string   `form:"name"`
+		Scopes    []string `form:"scopes"`
+		ExpiresIn int      `form:"expires_in"`
+	}
+	_ = c.BodyParser(&req)
+
+	plainToken, token, err := h.apiTokens.Create(c.UserContext(), user, req.Name, req.Scopes, req.ExpiresIn)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	// Tokenın açık hali hiçbir yerde saklanmaz; yalnızca bu yanıtta gösterilir.
+	return renderer.Render(c, "auth/api_token_created", "layouts/auth", fiber.Map{
+		"Title":                      "API Tokenı",
+		"Token":                      token,
+		"PlainToken":                 plainToken,
+		renderer.FlashSuccessKeyView: "api_tokens.created",
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) RevokeAPIToken(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		return h.handleError(c, services.ErrUserNotFound, 0, "", "API Tokenı İptali")
+	}
+
+	id, _ := c.ParamsInt("id")
+	token, err := h.apiTokens.Revoke(c.UserContext(), userID, uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "api_tokens.revoked", "name", token.Name)
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
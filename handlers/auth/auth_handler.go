@@ -3,98 +3,89 @@ package handlers
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"net/http"
-	"os"
+	"net/url"
 
 	"zatrano/configs/logconfig"
+	"zatrano/configs/oauthconfig"
 	"zatrano/configs/sessionconfig"
 	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
 	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+	"zatrano/pkg/oauthprovider"
 	"zatrano/pkg/renderer"
 	"zatrano/requests"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
 	"go.uber.org/zap"
 )
 
 type AuthHandler struct {
-	service    services.IAuthService
-	mailSender services.IMailService
+	service        services.IAuthService
+	userSessions   services.IUserSessionService
+	twoFactor      services.ITwoFactorService
+	loginThrottle  services.ILoginThrottleService
+	identities     services.IUserIdentityService
+	passkeys       services.IWebAuthnService
+	apiTokens      services.IAPITokenService
+	oauthProviders *oauthprovider.Registry
 }
 
 func NewAuthHandler() *AuthHandler {
 	return &AuthHandler{
-		service:    services.NewAuthService(),
-		mailSender: services.NewMailService(),
+		service:        services.NewAuthService(),
+		userSessions:   services.NewUserSessionService(),
+		twoFactor:      services.NewTwoFactorService(),
+		loginThrottle:  services.NewLoginThrottleService(),
+		identities:     services.NewUserIdentityService(),
+		passkeys:       services.NewWebAuthnService(),
+		apiTokens:      services.NewAPITokenService(),
+		oauthProviders: oauthconfig.Registry(),
 	}
 }
 
+// handleErrorRedirects, hata mesajının gösterileceği sayfa giriş ekranından farklı olan hatalardır.
+var handleErrorRedirects = map[error]string{
+	services.ErrCurrentPasswordIncorrect: "/auth/profile",
+	services.ErrPasswordTooShort:         "/auth/profile",
+	services.ErrPasswordSameAsOld:        "/auth/profile",
+}
+
 func (h *AuthHandler) handleError(c *fiber.Ctx, err error, userID uint, email string, action string) error {
-	var (
-		errMsg         string
-		flashKey       = flashmessages.FlashErrorKey
-		redirectTarget = "/auth/login"
-		logoutUser     = false
-	)
-
-	errorMessages := map[error]struct {
-		message       string
-		redirect      string
-		shouldLogout  bool
-		logAdditional []zap.Field
-	}{
-		services.ErrInvalidCredentials: {
-			message: "Kullanıcı adı veya şifre hatalı.",
-		},
-		services.ErrUserInactive: {
-			message: "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin.",
-		},
-		services.ErrUserNotFound: {
-			message:      "Kullanıcı bulunamadı, lütfen tekrar giriş yapın.",
-			shouldLogout: true,
-			logAdditional: []zap.Field{
-				zap.Uint("user_id", userID),
-			},
-		},
-		services.ErrCurrentPasswordIncorrect: {
-			message:  "Mevcut şifreniz hatalı.",
-			redirect: "/auth/profile",
-		},
-		services.ErrPasswordTooShort: {
-			message:  "Şifre çok kısa.",
-			redirect: "/auth/profile",
-		},
-		services.ErrPasswordSameAsOld: {
-			message:  "Yeni şifre eski şifre ile aynı olamaz.",
-			redirect: "/auth/profile",
-		},
-	}
-
-	if customErr, ok := errorMessages[err]; ok {
-		errMsg = customErr.message
-		if customErr.redirect != "" {
-			redirectTarget = customErr.redirect
+	appErr := apperrors.From(err)
+	message := apperrors.Key(appErr)
+
+	redirectTarget := "/auth/login"
+	for target, redirect := range handleErrorRedirects {
+		if errors.Is(err, target) {
+			redirectTarget = redirect
+			break
 		}
-		logoutUser = customErr.shouldLogout
-	} else {
-		errMsg = "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin."
+	}
+
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		logconfig.Log.Warn(action+": Kullanıcı bulunamadı", zap.Uint("user_id", userID))
+		message = "auth.session_user_not_found"
+		h.destroySession(c)
+	case appErr.Status >= fiber.StatusInternalServerError:
 		logconfig.Log.Error(action+": Beklenmeyen hata",
 			zap.Uint("user_id", userID),
 			zap.String("email", email),
 			zap.Error(err))
 	}
 
-	if logoutUser {
-		h.destroySession(c)
-	}
-
-	_ = flashmessages.SetFlashMessage(c, flashKey, errMsg)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
 	return c.Redirect(redirectTarget, fiber.StatusSeeOther)
 }
 
 func (h *AuthHandler) getSessionUser(c *fiber.Ctx) (uint, error) {
-	if userID, ok := c.Locals("userID").(uint); ok {
+	if userID := auth.CurrentUserID(c); userID != 0 {
 		return userID, nil
 	}
 
@@ -122,6 +113,9 @@ func (h *AuthHandler) destroySession(c *fiber.Ctx) {
 		logconfig.Log.Warn("Oturum yok edilemedi (zaten yok olabilir)", zap.Error(err))
 		return
 	}
+	if err := h.userSessions.Forget(c.UserContext(), sess.ID()); err != nil {
+		logconfig.Log.Warn("Oturum kaydı silinemedi", zap.Error(err))
+	}
 	if err := sess.Destroy(); err != nil {
 		logconfig.Log.Error("Oturum yok edilemedi", zap.Error(err))
 	}
@@ -144,17 +138,18 @@ func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
 	return renderer.Render(c, "auth/login", "layouts/auth", fiber.Map{
 		"Title":               "Giriş",
 		"PendingVerification": pendingVerification,
+		"OAuthProviders":      h.oauthProviderViews(),
 	}, http.StatusOK)
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
-	req, ok := c.Locals("loginRequest").(requests.LoginRequest)
+	req, ok := requests.Get[requests.LoginRequest](c)
 	if !ok {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	user, err := h.service.Authenticate(req.Email, req.Password)
+	user, err := h.service.Authenticate(req.Email, req.Password, c.IP())
 	if err != nil {
 		return h.handleError(c, err, 0, req.Email, "Login")
 	}
@@ -168,6 +163,43 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Login")
 	}
 
+	if user.TwoFactorEnabled {
+		return beginTwoFactorChallenge(c, sess, user)
+	}
+
+	return h.completeLogin(c, sess, user, "auth.logged_in")
+}
+
+// completeLogin, kimliği doğrulanmış kullanıcı için oturumu açar ve kullanıcı tipine göre yönlendirir.
+// successKey ve args başarı mesajının çeviri anahtarı ve yer tutucularıdır.
+func (h *AuthHandler) completeLogin(c *fiber.Ctx, sess *session.Session, user *models.User, successKey string, args ...interface{}) error {
+	path, err := h.startUserSession(c, sess, user)
+	if err != nil {
+		if errors.Is(err, errInvalidUserType) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.invalid_user_type")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Login")
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, successKey, args...)
+	return c.Redirect(path, fiber.StatusFound)
+}
+
+var errInvalidUserType = errors.New("geçersiz kullanıcı tipi")
+
+// startUserSession, oturuma kullanıcıyı yazar ve kullanıcı tipine göre yönlendirilecek adresi döner.
+func (h *AuthHandler) startUserSession(c *fiber.Ctx, sess *session.Session, user *models.User) (string, error) {
+	redirectPaths := map[models.UserType]string{
+		models.Panel:     "/panel/home",
+		models.Dashboard: "/dashboard/home",
+	}
+	path, ok := redirectPaths[user.Type]
+	if !ok {
+		h.destroySession(c)
+		return "", errInvalidUserType
+	}
+
 	sess.Set("user_id", user.ID)
 	sess.Set("user_type", string(user.Type))
 	if err := sess.Save(); err != nil {
@@ -175,47 +207,116 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 			zap.Uint("user_id", user.ID),
 			zap.String("email", user.Email),
 			zap.Error(err))
-		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Login")
+		return "", err
 	}
+	_ = h.userSessions.Track(c.UserContext(), user.ID, sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent))
+	return path, nil
+}
 
-	redirectPaths := map[models.UserType]string{
-		models.Panel:     "/panel/home",
-		models.Dashboard: "/dashboard/home",
+func (h *AuthHandler) Profile(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+	if user == nil {
+		logconfig.Log.Warn("Profil: Geçersiz oturum")
+		h.destroySession(c)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.session_expired")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	if path, ok := redirectPaths[user.Type]; ok {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla giriş yapıldı")
-		return c.Redirect(path, fiber.StatusFound)
+	userSessions, err := h.userSessions.GetActiveSessions(user.ID)
+	if err != nil {
+		userSessions = []models.UserSession{}
 	}
 
-	_ = sess.Destroy()
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kullanıcı tipi")
-	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	var currentDeviceID uint
+	if sess, err := sessionconfig.SessionStart(c); err == nil {
+		for _, userSession := range userSessions {
+			if userSession.SessionID == sess.ID() {
+				currentDeviceID = userSession.ID
+				break
+			}
+		}
+	}
+
+	var recoveryCodesRemaining int64
+	if user.TwoFactorEnabled {
+		recoveryCodesRemaining, _ = h.twoFactor.RemainingRecoveryCodes(user.ID)
+	}
+
+	identities, linkableProviders, canUnlink := h.profileIdentities(user)
+
+	passkeys, err := h.passkeys.GetCredentials(user.ID)
+	if err != nil {
+		passkeys = []models.WebAuthnCredential{}
+	}
+
+	apiTokens, err := h.apiTokens.GetTokens(user.ID)
+	if err != nil {
+		apiTokens = []models.APIToken{}
+	}
+
+	return renderer.Render(c, "auth/profile", "layouts/auth", fiber.Map{
+		"Title":                  "Profilim",
+		"User":                   user,
+		"Devices":                userSessions,
+		"CurrentDeviceID":        currentDeviceID,
+		"RecoveryCodesRemaining": recoveryCodesRemaining,
+		"Identities":             identities,
+		"LinkableProviders":      linkableProviders,
+		"CanUnlinkIdentity":      canUnlink,
+		"Passkeys":               passkeys,
+		"APITokens":              apiTokens,
+		"APITokenScopes":         h.apiTokens.AvailableScopes(user),
+		"APITokenExpiryDays":     services.APITokenExpiryDays,
+	}, http.StatusOK)
 }
 
-func (h *AuthHandler) Profile(c *fiber.Ctx) error {
+func (h *AuthHandler) RevokeDevice(c *fiber.Ctx) error {
 	userID, err := h.getSessionUser(c)
 	if err != nil {
-		logconfig.Log.Warn("Profil: Geçersiz oturum", zap.Error(err))
+		return h.handleError(c, services.ErrUserNotFound, 0, "", "Cihaz Oturumu Kapatma")
+	}
+
+	var currentSessionID string
+	if sess, err := sessionconfig.SessionStart(c); err == nil {
+		currentSessionID = sess.ID()
+	}
+
+	id, _ := c.ParamsInt("id")
+	revoked, err := h.userSessions.Revoke(c.UserContext(), userID, uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "sessions.revoke_failed")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	if revoked.SessionID == currentSessionID {
 		h.destroySession(c)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum, lütfen tekrar giriş yapın.")
-		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "sessions.current_revoked")
+		return c.Redirect("/auth/login", fiber.StatusFound)
 	}
 
-	user, err := h.service.GetUserProfile(userID)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "sessions.revoked")
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+func (h *AuthHandler) RevokeAllDevices(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
 	if err != nil {
-		return h.handleError(c, err, userID, "", "Profil")
+		return h.handleError(c, services.ErrUserNotFound, 0, "", "Tüm Oturumları Kapatma")
 	}
 
-	return renderer.Render(c, "auth/profile", "layouts/auth", fiber.Map{
-		"Title": "Profilim",
-		"User":  user,
-	}, http.StatusOK)
+	if _, err := h.userSessions.RevokeAll(c.UserContext(), userID, ""); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "sessions.revoke_all_failed")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	h.destroySession(c)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "sessions.all_revoked")
+	return c.Redirect("/auth/login", fiber.StatusFound)
 }
 
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 	h.destroySession(c)
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Başarıyla çıkış yapıldı.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.logged_out")
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
 
@@ -223,14 +324,14 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 	userID, err := h.getSessionUser(c)
 	if err != nil {
 		h.destroySession(c)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.session_expired")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	req, ok := c.Locals("updatePasswordRequest").(requests.UpdatePasswordRequest)
+	req, ok := requests.Get[requests.UpdatePasswordRequest](c)
 	if !ok {
 		logconfig.SLog.Warn("Parola güncelleme: Geçersiz istek formatı")
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek formatı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
 		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
 	}
 
@@ -238,14 +339,34 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		return h.handleError(c, err, userID, "", "Parola Güncelleme")
 	}
 
+	if _, err := h.userSessions.RevokeAll(c.UserContext(), userID, ""); err != nil {
+		logconfig.Log.Error("Parola güncelleme: Diğer oturumlar kapatılamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
 	h.destroySession(c)
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Şifre başarıyla güncellendi. Lütfen yeni şifrenizle tekrar giriş yapın.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.password_updated")
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
 
+// UpdateLocale, dil tercihini kullanıcı kaydına ve çereze yazar; tercih sonraki
+// girişlerde ve diğer cihazlarda da geçerli olur.
+func (h *AuthHandler) UpdateLocale(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+
+	locale, err := h.service.UpdateLocale(c.UserContext(), user.ID, c.FormValue("locale"))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	i18n.SetLocaleCookie(c, locale)
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "profile.locale_updated")
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
 func (h *AuthHandler) ShowRegister(c *fiber.Ctx) error {
 	return renderer.Render(c, "auth/register", "layouts/auth", fiber.Map{
-		"Title": "Kayıt Ol",
+		"Title":          "Kayıt Ol",
+		"OAuthProviders": h.oauthProviderViews(),
 	}, http.StatusOK)
 }
 
@@ -258,9 +379,9 @@ func generateToken() (string, error) {
 }
 
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
-	req, ok := c.Locals("registerRequest").(requests.RegisterRequest)
+	req, ok := requests.Get[requests.RegisterRequest](c)
 	if !ok {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz kayıt isteği")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
 		return c.Redirect("/auth/register", fiber.StatusSeeOther)
 	}
 
@@ -272,32 +393,17 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		Type:     models.Panel,
 	}
 
-	resetToken, err := generateToken()
-	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Reset token oluşturulamadı")
-		return c.Redirect("/auth/register", fiber.StatusSeeOther)
-	}
-	user.ResetToken = resetToken
-
-	verificationToken, err := generateToken()
-	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Verification token oluşturulamadı")
-		return c.Redirect("/auth/register", fiber.StatusSeeOther)
-	}
-	user.VerificationToken = verificationToken
-
 	ctx := c.UserContext()
 	if err := h.service.CreateUser(ctx, user); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı oluşturulamadı. Lütfen tekrar deneyin.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.register_failed")
 		return c.Redirect("/auth/register", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kayıt işlemi başarıyla tamamlandı. Lütfen email adresinizi doğrulayın.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.registered")
 
-	baseURL := os.Getenv("APP_BASE_URL")
-	verificationLink := baseURL + "/auth/verify-email?token=" + verificationToken
-	emailBody := "Lütfen email adresinizi doğrulamak için aşağıdaki bağlantıya tıklayın: " + verificationLink
-	_ = h.mailSender.SendMail(user.Email, "Email Doğrulama", emailBody)
+	if err := h.service.SendVerificationLink(user); err != nil {
+		logconfig.Log.Warn("Kayıt: Doğrulama e-postası gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
 
 	return renderer.Render(c, "auth/verify_email_notice", "layouts/auth", nil, http.StatusOK)
 }
@@ -309,25 +415,25 @@ func (h *AuthHandler) ShowForgotPassword(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
-	req, ok := c.Locals("forgotPasswordRequest").(requests.ForgotPasswordRequest)
+	req, ok := requests.Get[requests.ForgotPasswordRequest](c)
 	if !ok {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
 
 	if err := h.service.SendPasswordResetLink(req.Email); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şifre sıfırlama bağlantısı gönderilemedi. Lütfen tekrar deneyin.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.password_reset_send_failed")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Şifre sıfırlama bağlantısı başarıyla gönderildi. Lütfen emailinizi kontrol edin.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.password_reset_sent")
 	return c.Redirect("/auth/login", fiber.StatusSeeOther)
 }
 
 func (h *AuthHandler) ShowResetPassword(c *fiber.Ctx) error {
 	token := c.Query("token")
 	if token == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz veya eksik token.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.token_missing")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
 
@@ -337,35 +443,63 @@ func (h *AuthHandler) ShowResetPassword(c *fiber.Ctx) error {
 	}, http.StatusOK)
 }
 
+// ResetPasswordRedirect, şifre sıfırlama formu doğrulanamadığında kullanıcıyı token'ı
+// kaybetmeden aynı forma geri gönderir.
+func ResetPasswordRedirect(c *fiber.Ctx) string {
+	return resetPasswordPath(c.FormValue("token"))
+}
+
+func resetPasswordPath(token string) string {
+	return "/auth/reset-password?token=" + url.QueryEscape(token)
+}
+
 func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
-	req, ok := c.Locals("resetPasswordRequest").(requests.ResetPasswordRequest)
+	req, ok := requests.Get[requests.ResetPasswordRequest](c)
 	if !ok || req.Token == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz veya eksik token.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.token_missing")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
 
-	if err := h.service.ResetPassword(req.Token, req.NewPassword); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Şifre sıfırlama işlemi başarısız oldu.")
-		return c.Redirect("/auth/reset-password", fiber.StatusSeeOther)
+	userID, err := h.service.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, services.ErrTokenInvalid) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.password_reset_link_invalid")
+			return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.password_reset_failed")
+		return c.Redirect(resetPasswordPath(req.Token), fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Şifreniz başarıyla sıfırlandı. Lütfen giriş yapın.")
+	// Parola sıfırlama hesabın ele geçirildiği şüphesiyle yapılıyor olabilir; açık
+	// oturumlar ve API tokenları eski parolayla elde edilmiş sayılır.
+	if _, err := h.userSessions.RevokeAll(c.UserContext(), userID, ""); err != nil {
+		logconfig.Log.Error("Parola sıfırlama: Oturumlar kapatılamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	if _, err := h.apiTokens.RevokeAll(c.UserContext(), userID); err != nil {
+		logconfig.Log.Error("Parola sıfırlama: API tokenları iptal edilemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.password_reset_done")
 	return c.Redirect("/auth/login", fiber.StatusSeeOther)
 }
 
 func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
 	token := c.Query("token")
 	if token == "" {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama tokeni eksik veya geçersiz.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.verification_token_missing")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
 
 	if err := h.service.VerifyEmail(token); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Email doğrulama başarısız.")
+		if errors.Is(err, services.ErrTokenInvalid) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.verification_link_invalid")
+			return c.Redirect("/auth/resend-verification", fiber.StatusSeeOther)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.verification_failed")
 		return c.Redirect("/auth/forgot-password", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Email başarıyla doğrulandı.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.email_verified")
 	return c.Redirect("/auth/login", fiber.StatusSeeOther)
 }
 
@@ -376,17 +510,43 @@ func (h *AuthHandler) ShowResendVerification(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
-	req, ok := c.Locals("resendVerificationRequest").(requests.ResendVerificationRequest)
+	req, ok := requests.Get[requests.ResendVerificationRequest](c)
 	if !ok {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Geçersiz istek")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
 		return c.Redirect("/auth/resend-verification", fiber.StatusSeeOther)
 	}
 
 	if err := h.service.ResendVerificationLink(req.Email); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Doğrulama linki gönderilemedi.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.verification_send_failed")
 		return c.Redirect("/auth/resend-verification", fiber.StatusSeeOther)
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Doğrulama linki e-posta adresinize gönderildi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.verification_sent")
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+// ShowUnlockAccount, kilit açma bağlantısını hemen tüketmez; e-posta tarayıcılarının
+// önizleme istekleri tokenı harcamasın diye işlemi bir form gönderimiyle tamamlatır.
+func (h *AuthHandler) ShowUnlockAccount(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.unlock_link_invalid")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "auth/unlock", "layouts/auth", fiber.Map{
+		"Title": "Hesap Kilidini Aç",
+		"Token": token,
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) UnlockAccount(c *fiber.Ctx) error {
+	token := c.FormValue("token")
+	if err := h.loginThrottle.UnlockWithToken(c.UserContext(), token); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.unlock_link_invalid")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "auth.unlocked")
 	return c.Redirect("/auth/login", fiber.StatusSeeOther)
 }
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/oauthprovider"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
+)
+
+const (
+	identityLinkPendingKey  = "identity_link_pending"
+	identityLinkUserKey     = "identity_link_user_id"
+	identityLinkStartedKey  = "identity_link_started_at"
+	identityLinkPendingTTL  = 10 * time.Minute
+	identityLinkConfirmPage = "/auth/link"
+)
+
+// beginIdentityLink, e-postası mevcut bir hesapla eşleşen sağlayıcı kimliğini oturumda
+// bekletir ve kullanıcıyı onay sayfasına yönlendirir. Onay olmadan giriş yapılmaz.
+func (h *AuthHandler) beginIdentityLink(c *fiber.Ctx, sess *session.Session, user *models.User, identity *oauthprovider.Identity) error {
+	encoded, err := json.Marshal(identity)
+	if err != nil {
+		return oauthFail(c, "identity.link_start_failed")
+	}
+
+	sess.Set(identityLinkPendingKey, string(encoded))
+	sess.Set(identityLinkUserKey, user.ID)
+	sess.Set(identityLinkStartedKey, time.Now().Unix())
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("Hesap bağlantısı oturuma yazılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return oauthFail(c, "auth.session_save_failed")
+	}
+	return c.Redirect(identityLinkConfirmPage, fiber.StatusSeeOther)
+}
+
+func clearIdentityLink(sess *session.Session) {
+	sess.Delete(identityLinkPendingKey)
+	sess.Delete(identityLinkUserKey)
+	sess.Delete(identityLinkStartedKey)
+}
+
+func pendingIdentityLink(sess *session.Session) (uint, *oauthprovider.Identity, bool) {
+	userID, ok := sess.Get(identityLinkUserKey).(uint)
+	if !ok || userID == 0 {
+		return 0, nil, false
+	}
+	startedAt, ok := sess.Get(identityLinkStartedKey).(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > identityLinkPendingTTL {
+		return 0, nil, false
+	}
+	encoded, _ := sess.Get(identityLinkPendingKey).(string)
+	var identity oauthprovider.Identity
+	if err := json.Unmarshal([]byte(encoded), &identity); err != nil || identity.Provider == "" {
+		return 0, nil, false
+	}
+	return userID, &identity, true
+}
+
+func (h *AuthHandler) loadIdentityLink(c *fiber.Ctx) (*session.Session, *models.User, *oauthprovider.Identity, bool) {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return nil, nil, nil, false
+	}
+	userID, identity, ok := pendingIdentityLink(sess)
+	if !ok {
+		clearIdentityLink(sess)
+		_ = sess.Save()
+		return sess, nil, nil, false
+	}
+	user, err := h.service.GetUserProfile(userID)
+	if err != nil {
+		return sess, nil, nil, false
+	}
+	return sess, user, identity, true
+}
+
+func (h *AuthHandler) ShowIdentityLink(c *fiber.Ctx) error {
+	_, user, identity, ok := h.loadIdentityLink(c)
+	if !ok {
+		return oauthFail(c, "identity.link_expired")
+	}
+
+	return renderer.Render(c, "auth/identity_link", "layouts/auth", fiber.Map{
+		"Title":        "Hesap Bağlantısı",
+		"ProviderName": h.oauthDisplayName(identity.Provider),
+		"Email":        user.Email,
+		"HasPassword":  user.Password != "",
+	}, http.StatusOK)
+}
+
+// ConfirmIdentityLinkWithPassword, mevcut hesabın parolasıyla bağlantıyı onaylar ve girişi tamamlar.
+func (h *AuthHandler) ConfirmIdentityLinkWithPassword(c *fiber.Ctx) error {
+	sess, user, identity, ok := h.loadIdentityLink(c)
+	if !ok {
+		return oauthFail(c, "identity.link_expired")
+	}
+
+	authenticated, err := h.service.Authenticate(user.Email, c.FormValue("password"), c.IP())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "identity.password_incorrect")
+			return c.Redirect(identityLinkConfirmPage, fiber.StatusSeeOther)
+		}
+		clearIdentityLink(sess)
+		_ = sess.Save()
+		return h.handleError(c, err, user.ID, user.Email, "Hesap Bağlantısı")
+	}
+
+	clearIdentityLink(sess)
+	if err := h.identities.Link(c.UserContext(), authenticated.ID, identity); err != nil {
+		_ = sess.Save()
+		return oauthFail(c, apperrors.Key(err))
+	}
+
+	if authenticated.TwoFactorEnabled {
+		return beginTwoFactorChallenge(c, sess, authenticated)
+	}
+	return h.completeLogin(c, sess, authenticated, "identity.linked_and_logged_in", "provider", h.oauthDisplayName(identity.Provider))
+}
+
+// SendIdentityLinkEmail, bağlantı onayını hesabın e-posta adresine gönderir.
+func (h *AuthHandler) SendIdentityLinkEmail(c *fiber.Ctx) error {
+	sess, user, identity, ok := h.loadIdentityLink(c)
+	if !ok {
+		return oauthFail(c, "identity.link_expired")
+	}
+
+	clearIdentityLink(sess)
+	_ = sess.Save()
+
+	if err := h.identities.SendLinkConfirmation(c.UserContext(), user, identity); err != nil {
+		return oauthFail(c, "identity.confirmation_send_failed")
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "identity.confirmation_sent")
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) ConfirmIdentityLinkWithToken(c *fiber.Ctx) error {
+	identity, err := h.identities.ConfirmLinkWithToken(c.UserContext(), c.Query("token"))
+	if err != nil {
+		return oauthFail(c, apperrors.Key(err))
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "identity.linked", "provider", h.oauthDisplayName(identity.Provider))
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) UnlinkIdentity(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+	if user == nil {
+		return h.handleError(c, services.ErrUserNotFound, 0, "", "Hesap Bağlantısını Kaldırma")
+	}
+
+	id, _ := c.ParamsInt("id")
+	identity, err := h.identities.Unlink(c.UserContext(), user, uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "identity.unlinked", "provider", h.oauthDisplayName(identity.Provider))
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+type identityView struct {
+	ID          uint
+	DisplayName string
+	Email       string
+	CreatedAt   time.Time
+	LastUsedAt  time.Time
+}
+
+// profileIdentities, profildeki bağlı hesaplar listesini ve henüz bağlanmamış sağlayıcıları hazırlar.
+func (h *AuthHandler) profileIdentities(user *models.User) ([]identityView, []oauthProviderView, bool) {
+	identities, err := h.identities.GetIdentities(user.ID)
+	if err != nil {
+		identities = []models.UserIdentity{}
+	}
+
+	linked := make(map[string]bool, len(identities))
+	views := make([]identityView, 0, len(identities))
+	for _, identity := range identities {
+		linked[identity.Provider] = true
+		views = append(views, identityView{
+			ID:          identity.ID,
+			DisplayName: h.oauthDisplayName(identity.Provider),
+			Email:       identity.Email,
+			CreatedAt:   identity.CreatedAt,
+			LastUsedAt:  identity.LastUsedAt,
+		})
+	}
+
+	var linkable []oauthProviderView
+	for _, provider := range h.oauthProviderViews() {
+		if !linked[provider.Name] {
+			linkable = append(linkable, provider)
+		}
+	}
+
+	canUnlink := user.Password != "" || len(identities) > 1
+	return views, linkable, canUnlink
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/renderer"
+	"zatrano/requests"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const magicLinkSentMessage = "auth.magic_link_sent"
+
+func (h *AuthHandler) RequestMagicLink(c *fiber.Ctx) error {
+	req, ok := requests.Get[requests.MagicLinkRequest](c)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.loginThrottle.RegisterMagicLinkRequest(c.UserContext(), req.Email, c.IP()); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	// Hesabın varlığı veya durumu yanıttan anlaşılmasın diye bu hatalar da başarılı mesajıyla sonuçlanır.
+	err := h.service.SendMagicLink(req.Email)
+	if err != nil &&
+		!errors.Is(err, services.ErrUserNotFound) &&
+		!errors.Is(err, services.ErrUserInactive) &&
+		!errors.Is(err, services.ErrEmailNotVerified) {
+		logconfig.Log.Error("Giriş bağlantısı gönderilemedi", zap.String("email", req.Email), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.magic_link_send_failed")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, magicLinkSentMessage)
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+// ShowMagicLink, bağlantıyı hemen tüketmez; e-posta tarayıcılarının önizleme istekleri
+// tek kullanımlık tokenı harcamasın diye girişi bir form gönderimiyle tamamlatır.
+func (h *AuthHandler) ShowMagicLink(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.token_missing")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "auth/magic_link", "layouts/auth", fiber.Map{
+		"Title": "Giriş Bağlantısı",
+		"Token": token,
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) MagicLinkLogin(c *fiber.Ctx) error {
+	user, err := h.service.LoginWithMagicLink(c.FormValue("token"))
+	if err != nil {
+		return h.handleError(c, err, 0, "", "Giriş Bağlantısı")
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		logconfig.Log.Error("Oturum başlatılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return h.handleError(c, fiber.ErrInternalServerError, user.ID, user.Email, "Giriş Bağlantısı")
+	}
+
+	if user.TwoFactorEnabled {
+		return beginTwoFactorChallenge(c, sess, user)
+	}
+
+	return h.completeLogin(c, sess, user, "auth.logged_in")
+}
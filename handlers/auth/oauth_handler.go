@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/oauthprovider"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	oauthStateKey    = "oauth_state"
+	oauthNonceKey    = "oauth_nonce"
+	oauthProviderKey = "oauth_provider"
+	oauthIntentKey   = "oauth_intent"
+
+	oauthIntentLogin = "login"
+	oauthIntentLink  = "link"
+)
+
+type oauthProviderView struct {
+	Name        string
+	DisplayName string
+}
+
+func (h *AuthHandler) oauthProviderViews() []oauthProviderView {
+	providers := h.oauthProviders.All()
+	views := make([]oauthProviderView, 0, len(providers))
+	for _, provider := range providers {
+		views = append(views, oauthProviderView{Name: provider.Name(), DisplayName: provider.DisplayName()})
+	}
+	return views
+}
+
+func (h *AuthHandler) oauthDisplayName(name string) string {
+	if provider, err := h.oauthProviders.Get(name); err == nil {
+		return provider.DisplayName()
+	}
+	return name
+}
+
+func oauthFail(c *fiber.Ctx, key string, args ...interface{}) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, key, args...)
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) OAuthLogin(c *fiber.Ctx) error {
+	return h.startOAuth(c, oauthIntentLogin, "/auth/login")
+}
+
+// LinkIdentity, oturum açmış kullanıcının profilinden yeni bir sağlayıcı hesabı bağlama akışını başlatır.
+func (h *AuthHandler) LinkIdentity(c *fiber.Ctx) error {
+	return h.startOAuth(c, oauthIntentLink, "/auth/profile")
+}
+
+func (h *AuthHandler) startOAuth(c *fiber.Ctx, intent, failTarget string) error {
+	fail := func(key string, args ...interface{}) error {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, key, args...)
+		return c.Redirect(failTarget, fiber.StatusSeeOther)
+	}
+
+	provider, err := h.oauthProviders.Get(c.Params("provider"))
+	if err != nil {
+		return fail("oauth.unsupported_provider")
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return fail("auth.session_start_failed")
+	}
+
+	state, err := generateToken()
+	if err != nil {
+		return fail("oauth.state_create_failed")
+	}
+	nonce, err := generateToken()
+	if err != nil {
+		return fail("oauth.nonce_create_failed")
+	}
+
+	authURL := provider.AuthCodeURL(state, nonce)
+	if authURL == "" {
+		logconfig.Log.Error("OAuth yetkilendirme adresi oluşturulamadı", zap.String("provider", provider.Name()))
+		return fail("oauth.connect_failed", "provider", provider.DisplayName())
+	}
+
+	sess.Set(oauthStateKey, state)
+	sess.Set(oauthNonceKey, nonce)
+	sess.Set(oauthProviderKey, provider.Name())
+	sess.Set(oauthIntentKey, intent)
+	if err := sess.Save(); err != nil {
+		return fail("oauth.state_save_failed")
+	}
+
+	return c.Redirect(authURL, http.StatusTemporaryRedirect)
+}
+
+func (h *AuthHandler) OAuthCallback(c *fiber.Ctx) error {
+	provider, err := h.oauthProviders.Get(c.Params("provider"))
+	if err != nil {
+		return oauthFail(c, "oauth.unsupported_provider")
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return oauthFail(c, "auth.session_start_failed")
+	}
+
+	savedState, _ := sess.Get(oauthStateKey).(string)
+	nonce, _ := sess.Get(oauthNonceKey).(string)
+	savedProvider, _ := sess.Get(oauthProviderKey).(string)
+	intent, _ := sess.Get(oauthIntentKey).(string)
+	sess.Delete(oauthStateKey)
+	sess.Delete(oauthNonceKey)
+	sess.Delete(oauthProviderKey)
+	sess.Delete(oauthIntentKey)
+
+	failTarget := "/auth/login"
+	if intent == oauthIntentLink {
+		failTarget = "/auth/profile"
+	}
+	fail := func(key string, args ...interface{}) error {
+		_ = sess.Save()
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, key, args...)
+		return c.Redirect(failTarget, fiber.StatusSeeOther)
+	}
+
+	state := c.Query("state")
+	if state == "" || savedState == "" || state != savedState || savedProvider != provider.Name() {
+		return fail("oauth.state_invalid")
+	}
+
+	if errParam := c.Query("error"); errParam != "" {
+		logconfig.Log.Warn("OAuth sağlayıcısı hata döndürdü",
+			zap.String("provider", provider.Name()),
+			zap.String("error", errParam),
+			zap.String("description", c.Query("error_description")))
+		return fail("oauth.cancelled", "provider", provider.DisplayName())
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		return fail("oauth.code_missing")
+	}
+
+	identity, err := provider.Exchange(c.UserContext(), code, nonce)
+	if err != nil {
+		logconfig.Log.Error("OAuth kimlik bilgisi alınamadı", zap.String("provider", provider.Name()), zap.Error(err))
+		if errors.Is(err, oauthprovider.ErrMissingEmail) {
+			return fail("oauth.email_missing", "provider", provider.DisplayName())
+		}
+		return fail("oauth.userinfo_failed")
+	}
+
+	if intent == oauthIntentLink {
+		userID, err := sessionconfig.SessionUserID(sess)
+		if err != nil || userID == 0 {
+			failTarget = "/auth/login"
+			return fail("oauth.link_requires_login")
+		}
+		if err := h.identities.Link(c.UserContext(), userID, identity); err != nil {
+			return fail(apperrors.Key(err))
+		}
+		_ = sess.Save()
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "oauth.linked", "provider", provider.DisplayName())
+		return c.Redirect("/auth/profile", fiber.StatusFound)
+	}
+
+	user, err := h.identities.Resolve(c.UserContext(), identity)
+	if errors.Is(err, services.ErrIdentityLinkRequired) {
+		return h.beginIdentityLink(c, sess, user, identity)
+	}
+	if err != nil {
+		return fail("oauth.login_failed")
+	}
+
+	if !user.Status {
+		return fail("oauth.account_inactive")
+	}
+
+	if user.TwoFactorEnabled {
+		return beginTwoFactorChallenge(c, sess, user)
+	}
+
+	return h.completeLogin(c, sess, user, "oauth.logged_in", "provider", provider.DisplayName())
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"go.uber.org/zap"
+)
+
+const (
+	twoFactorPendingUserKey  = "2fa_pending_user_id"
+	twoFactorPendingAtKey    = "2fa_pending_at"
+	twoFactorSetupSecretKey  = "2fa_setup_secret"
+	twoFactorChallengeTTL    = 5 * time.Minute
+	twoFactorChallengeTarget = "/auth/2fa"
+)
+
+// beginTwoFactorChallenge, parolası doğrulanan kullanıcıyı oturum açmadan önce
+// ikinci adım sayfasına yönlendirir. user_id bu aşamada oturuma yazılmaz.
+func beginTwoFactorChallenge(c *fiber.Ctx, sess *session.Session, user *models.User) error {
+	sess.Delete("user_id")
+	sess.Delete("user_type")
+	sess.Set(twoFactorPendingUserKey, user.ID)
+	sess.Set(twoFactorPendingAtKey, time.Now().Unix())
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama oturumu kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.session_save_failed")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	return c.Redirect(twoFactorChallengeTarget, fiber.StatusSeeOther)
+}
+
+func clearTwoFactorChallenge(sess *session.Session) {
+	sess.Delete(twoFactorPendingUserKey)
+	sess.Delete(twoFactorPendingAtKey)
+}
+
+func pendingTwoFactorUserID(sess *session.Session) (uint, bool) {
+	userID, ok := sess.Get(twoFactorPendingUserKey).(uint)
+	if !ok || userID == 0 {
+		return 0, false
+	}
+	startedAt, ok := sess.Get(twoFactorPendingAtKey).(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > twoFactorChallengeTTL {
+		return 0, false
+	}
+	return userID, true
+}
+
+func (h *AuthHandler) ShowTwoFactorChallenge(c *fiber.Ctx) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	if _, ok := pendingTwoFactorUserID(sess); !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "two_factor.challenge_expired")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "auth/two_factor_challenge", "layouts/auth", fiber.Map{
+		"Title": "İki Adımlı Doğrulama",
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) VerifyTwoFactorChallenge(c *fiber.Ctx) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	userID, ok := pendingTwoFactorUserID(sess)
+	if !ok {
+		clearTwoFactorChallenge(sess)
+		_ = sess.Save()
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "two_factor.challenge_expired")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	user, err := h.service.GetUserProfile(userID)
+	if err != nil || !user.Status {
+		clearTwoFactorChallenge(sess)
+		_ = sess.Save()
+		return h.handleError(c, services.ErrUserInactive, userID, "", "İki Adımlı Doğrulama")
+	}
+
+	// Hatalı denemeler kullanıcı bazında tutulur; parolayla yeniden giriş yapmak
+	// yeni deneme hakkı kazandırmaz.
+	if err := h.loginThrottle.CheckTwoFactor(c.UserContext(), userID); err != nil {
+		return h.rejectTwoFactorChallenge(c, sess, userID)
+	}
+
+	if err := h.twoFactor.Verify(c.UserContext(), user, c.FormValue("code")); err != nil {
+		if errors.Is(err, services.ErrTwoFactorGeneric) {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+			return c.Redirect(twoFactorChallengeTarget, fiber.StatusSeeOther)
+		}
+		if lockErr := h.loginThrottle.RegisterTwoFactorFailure(c.UserContext(), userID); lockErr != nil {
+			return h.rejectTwoFactorChallenge(c, sess, userID)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "two_factor.code_invalid")
+		return c.Redirect(twoFactorChallengeTarget, fiber.StatusSeeOther)
+	}
+
+	h.loginThrottle.RegisterTwoFactorSuccess(c.UserContext(), userID)
+	clearTwoFactorChallenge(sess)
+	return h.completeLogin(c, sess, user, "auth.logged_in")
+}
+
+func (h *AuthHandler) rejectTwoFactorChallenge(c *fiber.Ctx, sess *session.Session, userID uint) error {
+	clearTwoFactorChallenge(sess)
+	_ = sess.Save()
+	logconfig.Log.Warn("İki adımlı doğrulama deneme sınırı aşıldı", zap.Uint("user_id", userID))
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "two_factor.too_many_attempts")
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) ShowTwoFactorSetup(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+	if user.TwoFactorEnabled {
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	setup, err := h.twoFactor.GenerateSetup(user)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "two_factor.setup_failed")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	if err := sessionconfig.SetSessionValue(c, twoFactorSetupSecretKey, setup.Secret); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.session_save_failed")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "auth/two_factor_setup", "layouts/auth", fiber.Map{
+		"Title":    "İki Adımlı Doğrulama Kurulumu",
+		"Secret":   setup.Secret,
+		"QRCode":   template.URL(setup.QRCodeURI),
+		"Required": user.TwoFactorRequired,
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/2fa/setup", fiber.StatusSeeOther)
+	}
+	secret, _ := sess.Get(twoFactorSetupSecretKey).(string)
+
+	codes, err := h.twoFactor.Enable(c.UserContext(), user, secret, c.FormValue("code"))
+	if err != nil {
+		if errors.Is(err, services.ErrTwoFactorAlreadyEnabled) {
+			return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "two_factor.setup_code_invalid")
+		return c.Redirect("/auth/2fa/setup", fiber.StatusSeeOther)
+	}
+
+	sess.Delete(twoFactorSetupSecretKey)
+	_ = sess.Save()
+
+	return renderer.Render(c, "auth/two_factor_recovery_codes", "layouts/auth", fiber.Map{
+		"Title":                      "Kurtarma Kodları",
+		"Codes":                      codes,
+		renderer.FlashSuccessKeyView: "two_factor.enabled",
+	}, http.StatusOK)
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+
+	if err := h.twoFactor.Disable(c.UserContext(), user, c.FormValue("password")); err != nil {
+		message := "two_factor.disable_failed"
+		switch {
+		case errors.Is(err, services.ErrCurrentPasswordIncorrect):
+			message = "auth.current_password_incorrect"
+		case errors.Is(err, services.ErrTwoFactorEnforced):
+			message = "two_factor.enforced"
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "two_factor.disabled")
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(c.UserContext(), user, c.FormValue("password"))
+	if err != nil {
+		message := "two_factor.recovery_codes_failed"
+		if errors.Is(err, services.ErrCurrentPasswordIncorrect) {
+			message = "auth.current_password_incorrect"
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	return renderer.Render(c, "auth/two_factor_recovery_codes", "layouts/auth", fiber.Map{
+		"Title":                      "Kurtarma Kodları",
+		"Codes":                      codes,
+		renderer.FlashSuccessKeyView: "two_factor.recovery_codes_regenerated",
+	}, http.StatusOK)
+}
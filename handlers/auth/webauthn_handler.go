@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	webAuthnRegistrationKey = "webauthn_registration"
+	webAuthnLoginKey        = "webauthn_login"
+)
+
+// errPasskeyUserTypeInvalid, passkey ile doğrulanan kullanıcının tipi bir panele karşılık gelmediğinde döner.
+var errPasskeyUserTypeInvalid = apperrors.Forbidden("passkey_user_type_invalid", "Geçersiz kullanıcı tipi.")
+
+// Passkey törenleri tarayıcıdaki navigator.credentials çağrılarıyla yürütüldüğü için
+// bu uç noktalar JSON döner; başarılı sonuçta istemci "redirect" adresine gider.
+
+func (h *AuthHandler) BeginPasskeyRegistration(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+	if user == nil {
+		return apperrors.Respond(c, apperrors.ErrUnauthorized)
+	}
+
+	options, state, err := h.passkeys.BeginRegistration(user)
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+	sess.Set(webAuthnRegistrationKey, state)
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("Passkey kayıt verisi oturuma yazılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return apperrors.Respond(c, err)
+	}
+	return c.JSON(options)
+}
+
+func (h *AuthHandler) FinishPasskeyRegistration(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+	if user == nil {
+		return apperrors.Respond(c, apperrors.ErrUnauthorized)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+	state, _ := sess.Get(webAuthnRegistrationKey).(string)
+	sess.Delete(webAuthnRegistrationKey)
+	_ = sess.Save()
+
+	credential, err := h.passkeys.FinishRegistration(c.UserContext(), user, state, c.Query("name"), bytes.NewReader(c.Body()))
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "passkeys.added", "name", credential.Name)
+	return c.JSON(fiber.Map{"redirect": "/auth/profile"})
+}
+
+func (h *AuthHandler) RevokePasskey(c *fiber.Ctx) error {
+	userID, err := h.getSessionUser(c)
+	if err != nil {
+		return h.handleError(c, services.ErrUserNotFound, 0, "", "Passkey Kaldırma")
+	}
+
+	id, _ := c.ParamsInt("id")
+	credential, err := h.passkeys.Revoke(c.UserContext(), userID, uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "passkeys.removed", "name", credential.Name)
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+func (h *AuthHandler) BeginPasskeyLogin(c *fiber.Ctx) error {
+	options, state, err := h.passkeys.BeginLogin()
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+	sess.Set(webAuthnLoginKey, state)
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("Passkey giriş verisi oturuma yazılamadı", zap.Error(err))
+		return apperrors.Respond(c, err)
+	}
+	return c.JSON(options)
+}
+
+// FinishPasskeyLogin, kullanıcı doğrulaması zorunlu tutulan passkey girişini ikinci adım
+// olarak kabul eder; bu yüzden TOTP doğrulaması ayrıca istenmez.
+func (h *AuthHandler) FinishPasskeyLogin(c *fiber.Ctx) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		return apperrors.Respond(c, err)
+	}
+	state, _ := sess.Get(webAuthnLoginKey).(string)
+	sess.Delete(webAuthnLoginKey)
+
+	user, err := h.passkeys.FinishLogin(c.UserContext(), state, bytes.NewReader(c.Body()))
+	if err != nil {
+		_ = sess.Save()
+		return apperrors.Respond(c, err)
+	}
+
+	clearTwoFactorChallenge(sess)
+	path, err := h.startUserSession(c, sess, user)
+	if err != nil {
+		if errors.Is(err, errInvalidUserType) {
+			return apperrors.Respond(c, errPasskeyUserTypeInvalid)
+		}
+		return apperrors.Respond(c, err)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "passkeys.logged_in")
+	return c.JSON(fiber.Map{"redirect": path})
+}
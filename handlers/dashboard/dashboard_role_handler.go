@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/renderer"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type RoleHandler struct {
+	roleService services.IRoleService
+}
+
+func NewRoleHandler() *RoleHandler {
+	svc := services.NewRoleService()
+	return &RoleHandler{roleService: svc}
+}
+
+type roleFormRequest struct {
+	Name          string `form:"name"`
+	Description   string `form:"description"`
+	PermissionIDs []uint `form:"permission_ids"`
+}
+
+func (r roleFormRequest) selectedPermissions() map[uint]bool {
+	selected := make(map[uint]bool, len(r.PermissionIDs))
+	for _, id := range r.PermissionIDs {
+		selected[id] = true
+	}
+	return selected
+}
+
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	var params queryparams.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		logconfig.Log.Warn("Rol listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = queryparams.DefaultListParams()
+	}
+	params.Filters = queryparams.ParseFilters(c.Queries())
+
+	params.Normalize()
+
+	paginatedResult, dbErr := h.roleService.GetAllRoles(params)
+
+	renderData := fiber.Map{
+		"Title":  "Roller",
+		"Result": paginatedResult,
+		"Params": params,
+	}
+	if dbErr != nil {
+		logconfig.Log.Error("Rol listesi DB Hatası", zap.Error(dbErr))
+		renderData[renderer.FlashErrorKeyView] = "roles.list_failed"
+		renderData["Result"] = &queryparams.PaginatedResult{
+			Data: []models.Role{},
+			Meta: queryparams.PaginationMeta{
+				CurrentPage: params.Page, PerPage: params.PerPage,
+			},
+		}
+	}
+	return renderer.Render(c, "dashboard/roles/list", "layouts/dashboard", renderData, http.StatusOK)
+}
+
+func (h *RoleHandler) ShowCreateRole(c *fiber.Ctx) error {
+	permissions, _ := h.roleService.GetAllPermissions()
+	return renderer.Render(c, "dashboard/roles/create", "layouts/dashboard", fiber.Map{
+		"Title":               "Yeni Rol Ekle",
+		"Permissions":         permissions,
+		"SelectedPermissions": map[uint]bool{},
+	})
+}
+
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	var req roleFormRequest
+	_ = c.BodyParser(&req)
+	req.Name = strings.TrimSpace(req.Name)
+
+	if req.Name == "" {
+		return h.renderRoleFormError(c, "dashboard/roles/create", "Yeni Rol Ekle", nil, req, "roles.name_required")
+	}
+
+	role := &models.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := h.roleService.CreateRole(c.UserContext(), role, req.PermissionIDs); err != nil {
+		return h.renderRoleFormError(c, "dashboard/roles/create", "Yeni Rol Ekle", nil, req, apperrors.Key(err))
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "roles.created")
+	return c.Redirect("/dashboard/roles", fiber.StatusFound)
+}
+
+func (h *RoleHandler) ShowUpdateRole(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	role, err := h.roleService.GetRoleByID(uint(id))
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "roles.not_found")
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	selected := make(map[uint]bool, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		selected[permission.ID] = true
+	}
+
+	permissions, _ := h.roleService.GetAllPermissions()
+	return renderer.Render(c, "dashboard/roles/update", "layouts/dashboard", fiber.Map{
+		"Title":               "Rol Düzenle",
+		"Role":                role,
+		"Permissions":         permissions,
+		"SelectedPermissions": selected,
+	})
+}
+
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	roleID := uint(id)
+
+	var req roleFormRequest
+	_ = c.BodyParser(&req)
+	req.Name = strings.TrimSpace(req.Name)
+
+	role, err := h.roleService.GetRoleByID(roleID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "roles.not_found")
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	if req.Name == "" {
+		return h.renderRoleFormError(c, "dashboard/roles/update", "Rol Düzenle", role, req, "roles.name_required")
+	}
+
+	roleData := &models.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := h.roleService.UpdateRole(c.UserContext(), roleID, roleData, req.PermissionIDs); err != nil {
+		return h.renderRoleFormError(c, "dashboard/roles/update", "Rol Düzenle", role, req, apperrors.Key(err))
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "roles.updated")
+	return c.Redirect("/dashboard/roles", fiber.StatusFound)
+}
+
+func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+
+	if err := h.roleService.DeleteRole(c.UserContext(), uint(id)); err != nil {
+		if apperrors.WantsJSON(c) {
+			return apperrors.Respond(c, err)
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	if apperrors.WantsJSON(c) {
+		return c.JSON(fiber.Map{"message": "Rol başarıyla silindi."})
+	}
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "roles.deleted")
+	return c.Redirect("/dashboard/roles", fiber.StatusFound)
+}
+
+func (h *RoleHandler) renderRoleFormError(c *fiber.Ctx, template, title string, role *models.Role, req roleFormRequest, message string) error {
+	permissions, _ := h.roleService.GetAllPermissions()
+	return renderer.Render(c, template, "layouts/dashboard", fiber.Map{
+		"Title":                    title,
+		"Role":                     role,
+		"Permissions":              permissions,
+		"SelectedPermissions":      req.selectedPermissions(),
+		renderer.FlashErrorKeyView: message,
+		renderer.FormDataKey:       req,
+	}, http.StatusBadRequest)
+}
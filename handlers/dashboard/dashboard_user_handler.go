@@ -2,13 +2,17 @@ package handlers
 
 import (
 	"net/http"
-	"strings"
+	"strconv"
 
 	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
 	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
 	"zatrano/pkg/flashmessages"
 	"zatrano/pkg/queryparams"
 	"zatrano/pkg/renderer"
+	"zatrano/requests"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,12 +20,20 @@ import (
 )
 
 type UserHandler struct {
-	userService services.IUserService
+	userService          services.IUserService
+	roleService          services.IRoleService
+	userSessionService   services.IUserSessionService
+	loginThrottleService services.ILoginThrottleService
 }
 
 func NewUserHandler() *UserHandler {
 	svc := services.NewUserService()
-	return &UserHandler{userService: svc}
+	return &UserHandler{
+		userService:          svc,
+		roleService:          services.NewRoleService(),
+		userSessionService:   services.NewUserSessionService(),
+		loginThrottleService: services.NewLoginThrottleService(),
+	}
 }
 
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
@@ -30,19 +42,9 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 		logconfig.Log.Warn("Kullanıcı listesi: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
 		params = queryparams.DefaultListParams()
 	}
+	params.Filters = queryparams.ParseFilters(c.Queries())
 
-	if params.Page <= 0 {
-		params.Page = queryparams.DefaultPage
-	}
-	if params.PerPage <= 0 || params.PerPage > queryparams.MaxPerPage {
-		params.PerPage = queryparams.DefaultPerPage
-	}
-	if params.SortBy == "" {
-		params.SortBy = queryparams.DefaultSortBy
-	}
-	if params.OrderBy == "" {
-		params.OrderBy = queryparams.DefaultOrderBy
-	}
+	params.Normalize()
 
 	paginatedResult, dbErr := h.userService.GetAllUsers(params)
 
@@ -53,7 +55,7 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	}
 	if dbErr != nil {
 		logconfig.Log.Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
-		renderData[renderer.FlashErrorKeyView] = "Kullanıcılar getirilirken bir hata oluştu."
+		renderData[renderer.FlashErrorKeyView] = "users.list_failed"
 		renderData["Result"] = &queryparams.PaginatedResult{
 			Data: []models.User{},
 			Meta: queryparams.PaginationMeta{
@@ -64,44 +66,98 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	return renderer.Render(c, "dashboard/users/list", "layouts/dashboard", renderData, http.StatusOK)
 }
 
+func (h *UserHandler) ListTrashedUsers(c *fiber.Ctx) error {
+	var params queryparams.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		logconfig.Log.Warn("Çöp kutusu: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = queryparams.DefaultListParams()
+	}
+	params.Filters = queryparams.ParseFilters(c.Queries())
+
+	params.Normalize()
+
+	paginatedResult, dbErr := h.userService.GetTrashedUsers(params)
+
+	renderData := fiber.Map{
+		"Title":  "Çöp Kutusu",
+		"Result": paginatedResult,
+		"Params": params,
+	}
+	if dbErr != nil {
+		logconfig.Log.Error("Çöp kutusu DB Hatası", zap.Error(dbErr))
+		renderData[renderer.FlashErrorKeyView] = "users.list_failed"
+		renderData["Result"] = &queryparams.PaginatedResult{
+			Data: []models.User{},
+			Meta: queryparams.PaginationMeta{
+				CurrentPage: params.Page, PerPage: params.PerPage,
+			},
+		}
+	}
+	return renderer.Render(c, "dashboard/users/trash", "layouts/dashboard", renderData, http.StatusOK)
+}
+
+func (h *UserHandler) RestoreUser(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+
+	if err := h.userService.RestoreUser(c.UserContext(), uint(id)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/dashboard/users/trash", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.restored")
+	return c.Redirect("/dashboard/users/trash", fiber.StatusFound)
+}
+
+func (h *UserHandler) ForceDeleteUser(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+
+	if err := h.userService.ForceDeleteUser(c.UserContext(), uint(id)); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/dashboard/users/trash", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.force_deleted")
+	return c.Redirect("/dashboard/users/trash", fiber.StatusFound)
+}
+
 func (h *UserHandler) ShowCreateUser(c *fiber.Ctx) error {
+	roles, _ := h.roleService.GetRoleList()
 	return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", fiber.Map{
-		"Title": "Yeni Kullanıcı Ekle",
+		"Title":         "Yeni Kullanıcı Ekle",
+		"Roles":         roles,
+		"SelectedRoles": map[uint]bool{},
 	})
 }
 
 func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
-	var req struct {
-		Name     string `form:"name"`
-		Email    string `form:"email"`
-		Password string `form:"password"`
-		Status   string `form:"status"`
-		Type     string `form:"type"`
-	}
-	_ = c.BodyParser(&req)
-
-	if req.Name == "" || req.Email == "" || req.Password == "" || req.Type == "" {
-		return renderUserFormError("Yeni Kullanıcı Ekle", req, "Ad, Hesap Adı, Şifre ve Kullanıcı Tipi alanları zorunludur.", c)
+	req, ok := requests.Get[requests.UserCreateRequest](c)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
+		return c.Redirect("/dashboard/users/create", fiber.StatusSeeOther)
 	}
 
-	status := req.Status == "true"
 	user := &models.User{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
-		Status:   status,
+		Status:   req.Status == "true",
 		Type:     models.UserType(req.Type),
 	}
 
-	if user.Type != models.Dashboard && user.Type != models.Panel {
-		return renderUserFormError("Yeni Kullanıcı Ekle", req, "Geçersiz kullanıcı tipi seçildi.", c)
+	if err := h.userService.CreateUser(c.UserContext(), user); err != nil {
+		return requests.RedirectWithInput(c, req, apperrors.Key(err), "/dashboard/users/create")
 	}
 
-	if err := h.userService.CreateUser(c.UserContext(), user); err != nil {
-		return renderUserFormError("Yeni Kullanıcı Ekle", req, "Kullanıcı oluşturulamadı: "+err.Error(), c)
+	// Rol ataması roles.manage yetkisi gerektirir; yetkisiz kullanıcıların gönderdiği
+	// role_ids yok sayılır.
+	if auth.Can(c, models.PermissionRolesManage) {
+		if err := h.userService.SyncUserRoles(c.UserContext(), user.ID, req.RoleIDs); err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "users.created_roles_failed")
+			return c.Redirect("/dashboard/users/update/"+strconv.Itoa(int(user.ID)), fiber.StatusSeeOther)
+		}
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.created")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
 
@@ -109,59 +165,113 @@ func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 	id, _ := c.ParamsInt("id")
 	user, err := h.userService.GetUserByID(uint(id))
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "users.not_found")
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
+	selected := make(map[uint]bool, len(user.Roles))
+	for _, role := range user.Roles {
+		selected[role.ID] = true
+	}
+
+	roles, _ := h.roleService.GetRoleList()
+	activeSessions, _ := h.userSessionService.GetActiveSessions(user.ID)
 	return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", fiber.Map{
-		"Title": "Kullanıcı Düzenle",
-		"User":  user,
+		"Title":          "Kullanıcı Düzenle",
+		"User":           user,
+		"Roles":          roles,
+		"SelectedRoles":  selected,
+		"ActiveSessions": activeSessions,
+		"AccountLocked":  h.loginThrottleService.IsLocked(c.UserContext(), user.Email),
 	})
 }
 
-func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
+func (h *UserHandler) UnlockUser(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	userID := uint(id)
+	redirectURL := "/dashboard/users/update/" + strconv.Itoa(int(userID))
+
+	user, err := h.userService.GetUserByID(userID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "users.not_found")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	if err := h.loginThrottleService.Unlock(c.UserContext(), user.Email); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.unlocked")
+	return c.Redirect(redirectURL, fiber.StatusFound)
+}
+
+func (h *UserHandler) TerminateUserSessions(c *fiber.Ctx) error {
 	id, _ := c.ParamsInt("id")
 	userID := uint(id)
+	redirectURL := "/dashboard/users/update/" + strconv.Itoa(int(userID))
 
-	var req struct {
-		Name     string `form:"name"`
-		Email    string `form:"email"`
-		Password string `form:"password"`
-		Status   string `form:"status"`
-		Type     string `form:"type"`
+	// Yönetici kendi oturumlarını kapatıyorsa mevcut oturumu korunur.
+	var exceptSessionID string
+	if userID == auth.CurrentUserID(c) {
+		if sess, err := sessionconfig.SessionStart(c); err == nil {
+			exceptSessionID = sess.ID()
+		}
 	}
-	_ = c.BodyParser(&req)
 
-	if req.Name == "" || req.Email == "" || req.Type == "" {
-		user, _ := h.userService.GetUserByID(userID)
-		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Zorunlu alanlar eksik.",
-			renderer.FormDataKey:       req,
-			"User":                     user,
-		}, http.StatusBadRequest)
+	count, err := h.userSessionService.RevokeAll(c.UserContext(), userID, exceptSessionID)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "users.sessions_revoke_failed")
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.sessions_revoked", "count", count)
+	return c.Redirect(redirectURL, fiber.StatusFound)
+}
+
+func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
+	id, _ := c.ParamsInt("id")
+	userID := uint(id)
+	redirectURL := "/dashboard/users/update/" + strconv.Itoa(int(userID))
+
+	req, ok := requests.Get[requests.UserUpdateRequest](c)
+	if !ok {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "errors.invalid_request_format")
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
 	}
 
 	userData := &models.User{
-		Name:   req.Name,
-		Email:  req.Email,
-		Status: req.Status == "true",
-		Type:   models.UserType(req.Type),
+		Name:              req.Name,
+		Email:             req.Email,
+		Status:            req.Status == "true",
+		Type:              models.UserType(req.Type),
+		TwoFactorRequired: req.TwoFactorRequired == "true",
 	}
 	if req.Password != "" {
 		userData.Password = req.Password
 	}
 
 	if err := h.userService.UpdateUser(c.UserContext(), userID, userData); err != nil {
-		user, _ := h.userService.GetUserByID(userID)
-		return renderer.Render(c, "dashboard/users/update", "layouts/dashboard", fiber.Map{
-			"Title":                    "Kullanıcı Düzenle",
-			renderer.FlashErrorKeyView: "Güncelleme hatası: " + err.Error(),
-			renderer.FormDataKey:       req,
-			"User":                     user,
-		}, http.StatusInternalServerError)
+		return requests.RedirectWithInput(c, req, apperrors.Key(err), redirectURL)
+	}
+
+	if auth.Can(c, models.PermissionRolesManage) {
+		if err := h.userService.SyncUserRoles(c.UserContext(), userID, req.RoleIDs); err != nil {
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "users.updated_roles_failed")
+			return c.Redirect(redirectURL, fiber.StatusSeeOther)
+		}
 	}
 
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla güncellendi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.updated")
+	return c.Redirect("/dashboard/users", fiber.StatusFound)
+}
+
+func (h *UserHandler) RequireTwoFactor(c *fiber.Ctx) error {
+	if err := h.userService.RequireTwoFactorForDashboardUsers(c.UserContext()); err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.two_factor_enforced")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
 
@@ -170,25 +280,16 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	userID := uint(id)
 
 	if err := h.userService.DeleteUser(c.UserContext(), userID); err != nil {
-		errMsg := "Kullanıcı silinemedi: " + err.Error()
-		if strings.Contains(c.Get("Accept"), "application/json") {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": errMsg})
+		if apperrors.WantsJSON(c) {
+			return apperrors.Respond(c, err)
 		}
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, errMsg)
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(err))
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 
-	if strings.Contains(c.Get("Accept"), "application/json") {
+	if apperrors.WantsJSON(c) {
 		return c.JSON(fiber.Map{"message": "Kullanıcı başarıyla silindi."})
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kullanıcı başarıyla silindi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "users.deleted")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
-
-func renderUserFormError(title string, req any, message string, c *fiber.Ctx) error {
-	return renderer.Render(c, "dashboard/users/create", "layouts/dashboard", fiber.Map{
-		"Title":                    title,
-		renderer.FlashErrorKeyView: message,
-		renderer.FormDataKey:       req,
-	}, http.StatusBadRequest)
-}
@@ -23,7 +23,7 @@ func NewPanelCardHandler() *PanelCardHandler {
 func (h *PanelCardHandler) ListPanelCards(c *fiber.Ctx) error {
 	cards, err := h.cardService.GetAllCards()
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kartlar alınamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "cards.list_failed")
 	}
 	return renderer.Render(c, "panel/cards/list", "layouts/panel", fiber.Map{
 		"Title": "Kartlar",
@@ -51,7 +51,7 @@ func (h *PanelCardHandler) CreatePanelCard(c *fiber.Ctx) error {
 			flashmessages.FlashErrorKey: "Kart kaydedilemedi.",
 		}, http.StatusInternalServerError)
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kart başarıyla eklendi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "cards.created")
 	return c.Redirect("/panel/cards", http.StatusFound)
 }
 
@@ -59,7 +59,7 @@ func (h *PanelCardHandler) ShowUpdatePanelCard(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
 	card, err := h.cardService.GetCardByID(uint(id))
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "cards.not_found")
 		return c.Redirect("/panel/cards", http.StatusSeeOther)
 	}
 	return renderer.Render(c, "panel/cards/update", "layouts/panel", fiber.Map{
@@ -83,16 +83,16 @@ func (h *PanelCardHandler) UpdatePanelCard(c *fiber.Ctx) error {
 			flashmessages.FlashErrorKey: "Kart güncellenemedi.",
 		}, http.StatusInternalServerError)
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kart başarıyla güncellendi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "cards.updated")
 	return c.Redirect("/panel/cards", http.StatusFound)
 }
 
 func (h *PanelCardHandler) DeletePanelCard(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
 	if err := h.cardService.DeleteCard(c.UserContext(), uint(id)); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kart silinemedi.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "cards.delete_failed")
 		return c.Redirect("/panel/cards", http.StatusSeeOther)
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Kart başarıyla silindi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "cards.deleted")
 	return c.Redirect("/panel/cards", http.StatusFound)
 }
@@ -24,7 +24,7 @@ func (h *PanelInvitationHandler) ListPanelInvitations(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uint)
 	invitations, err := h.invitationService.GetAllInvitations()
 	if err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiyeler alınamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "invitations.list_failed")
 	}
 	// Sadece ilgili kullanıcıya ait davetiyeleri filtrele
 	var userInvitations []models.Invitation
@@ -61,7 +61,7 @@ func (h *PanelInvitationHandler) CreatePanelInvitation(c *fiber.Ctx) error {
 			flashmessages.FlashErrorKey: "Davetiye kaydedilemedi.",
 		}, http.StatusInternalServerError)
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye başarıyla eklendi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "invitations.created")
 	return c.Redirect("/panel/invitations", http.StatusFound)
 }
 
@@ -70,7 +70,7 @@ func (h *PanelInvitationHandler) ShowUpdatePanelInvitation(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
 	invitation, err := h.invitationService.GetInvitationByID(uint(id))
 	if err != nil || invitation.UserID != userID {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "invitations.not_found")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 	return renderer.Render(c, "panel/invitations/update", "layouts/panel", fiber.Map{
@@ -91,7 +91,7 @@ func (h *PanelInvitationHandler) UpdatePanelInvitation(c *fiber.Ctx) error {
 	}
 	invitation, err := h.invitationService.GetInvitationByID(uint(id))
 	if err != nil || invitation.UserID != userID {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "invitations.not_found")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 	if err := h.invitationService.UpdateInvitation(c.UserContext(), uint(id), &req); err != nil {
@@ -100,7 +100,7 @@ func (h *PanelInvitationHandler) UpdatePanelInvitation(c *fiber.Ctx) error {
 			flashmessages.FlashErrorKey: "Davetiye güncellenemedi.",
 		}, http.StatusInternalServerError)
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye başarıyla güncellendi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "invitations.updated")
 	return c.Redirect("/panel/invitations", http.StatusFound)
 }
 
@@ -109,13 +109,13 @@ func (h *PanelInvitationHandler) DeletePanelInvitation(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
 	invitation, err := h.invitationService.GetInvitationByID(uint(id))
 	if err != nil || invitation.UserID != userID {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye bulunamadı.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "invitations.not_found")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
 	if err := h.invitationService.DeleteInvitation(c.UserContext(), uint(id)); err != nil {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Davetiye silinemedi.")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "invitations.delete_failed")
 		return c.Redirect("/panel/invitations", http.StatusSeeOther)
 	}
-	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "Davetiye başarıyla silindi.")
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashSuccessKey, "invitations.deleted")
 	return c.Redirect("/panel/invitations", http.StatusFound)
 }
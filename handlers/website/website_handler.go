@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"net/url"
+	"strings"
 
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/i18n"
 	"zatrano/pkg/renderer"
 
 	"github.com/gofiber/fiber/v2"
@@ -19,3 +23,27 @@ func (h *WebsiteHandler) ShowHomePage(c *fiber.Ctx) error {
 	mapData := fiber.Map{}
 	return renderer.Render(c, "website/home", "layouts/website", mapData, http.StatusOK)
 }
+
+// SwitchLocale, dil tercihini çereze yazar ve kullanıcıyı geldiği sayfaya döndürür.
+// Yalnızca aynı sitedeki yollara dönülür; Referer başka bir alan adını gösteriyorsa
+// ana sayfaya yönlendirilir.
+func (h *WebsiteHandler) SwitchLocale(c *fiber.Ctx) error {
+	locale, ok := i18n.Normalize(c.Params("locale"))
+	if !ok {
+		return apperrors.ErrNotFound
+	}
+	i18n.SetLocaleCookie(c, locale)
+	return c.Redirect(localReferer(c), fiber.StatusFound)
+}
+
+func localReferer(c *fiber.Ctx) string {
+	referer, err := url.Parse(c.Get(fiber.HeaderReferer))
+	if err != nil || (referer.Host != "" && referer.Host != c.Hostname()) ||
+		!strings.HasPrefix(referer.Path, "/") || strings.HasPrefix(referer.Path, "//") {
+		return "/"
+	}
+	if referer.RawQuery != "" {
+		return referer.Path + "?" + referer.RawQuery
+	}
+	return referer.Path
+}
@@ -1,38 +1,47 @@
 package middlewares
 
 import (
-	"context"
+	"sync"
 
 	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/auth"
 	"zatrano/pkg/flashmessages"
 	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// authService tüm istekler arasında paylaşılır; servis durumsuzdur.
+var authService = sync.OnceValue(services.NewAuthService)
+
+var userSessionService = sync.OnceValue(services.NewUserSessionService)
+
 func AuthMiddleware(c *fiber.Ctx) error {
-	userID, err := sessionconfig.GetUserIDFromSession(c)
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.session_invalid")
+		return c.Redirect("/auth/login")
+	}
+	userID, err := sessionconfig.SessionUserID(sess)
 	if err != nil || userID == 0 {
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Oturum bilgileri geçersiz")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.session_invalid")
 		return c.Redirect("/auth/login")
 	}
 
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
+	user, err := authService().GetAuthenticatedUser(userID)
 	if err != nil {
 		sessionconfig.DestroySession(c)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı bulunamadı")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.user_not_found")
 		return c.Redirect("/auth/login")
 	}
 
-	ctx := context.WithValue(c.Context(), "user_id", userID)
-	ctx = context.WithValue(ctx, "user_type", user.Type)
-	ctx = context.WithValue(ctx, "user_email", user.Email)
-	c.SetUserContext(ctx)
-
-	c.Locals("userID", userID)
-	c.Locals("userType", user.Type)
-	c.Locals("userEmail", user.Email)
+	auth.SetCurrentUser(c, user)
+	userSessionService().Touch(user.ID, sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent))
 
 	return c.Next()
 }
+
+func redirectUnauthenticated(c *fiber.Ctx) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.unauthenticated")
+	return c.Redirect("/auth/login")
+}
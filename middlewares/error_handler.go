@@ -0,0 +1,88 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/renderer"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// errorTemplates, özel şablonu bulunan durum kodlarıdır; diğerleri errors/error ile gösterilir.
+var errorTemplates = map[int]bool{
+	http.StatusForbidden:           true,
+	http.StatusNotFound:            true,
+	http.StatusInternalServerError: true,
+}
+
+// ErrorHandler, fiber.Config.ErrorHandler olarak kullanılır. API istemcilerine
+// RFC 7807 problem+json, tarayıcılara ise uygun layout içinde hata sayfası döner.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	appErr := apperrors.From(err)
+
+	fields := []zap.Field{
+		zap.Error(err),
+		zap.Int("status_code", appErr.Status),
+		zap.String("code", appErr.Code),
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.String("ip", c.IP()),
+	}
+	if appErr.Status >= http.StatusInternalServerError {
+		logconfig.Log.Error("İstek hatası", fields...)
+	} else {
+		logconfig.Log.Warn("İstek hatası", fields...)
+	}
+
+	if apperrors.WantsJSON(c) {
+		return apperrors.Respond(c, appErr)
+	}
+
+	if appErr.Status == http.StatusUnauthorized {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(appErr))
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	template := "errors/error"
+	if errorTemplates[appErr.Status] {
+		template = "errors/" + strconv.Itoa(appErr.Status)
+	}
+
+	message := apperrors.Localized(c, appErr)
+	renderErr := renderer.Render(c, template, errorLayout(c), fiber.Map{
+		"Title":   http.StatusText(appErr.Status),
+		"Status":  appErr.Status,
+		"Message": message,
+		"Fields":  appErr.Fields,
+	}, appErr.Status)
+	if renderErr != nil {
+		logconfig.Log.Error("Hata sayfası oluşturulamadı", zap.Error(renderErr))
+		return c.Status(appErr.Status).SendString(message)
+	}
+	return nil
+}
+
+// errorLayout, hatanın oluştuğu bölümün layout'unu seçer. Yönetim ve panel
+// layout'ları oturumdaki kullanıcıya ihtiyaç duyduğu için kullanıcı yoksa
+// site layout'u kullanılır.
+func errorLayout(c *fiber.Ctx) string {
+	path := c.Path()
+	user := auth.CurrentUser(c)
+	switch {
+	case strings.HasPrefix(path, "/dashboard") && user != nil && user.Type == models.Dashboard:
+		return "layouts/dashboard"
+	case strings.HasPrefix(path, "/panel") && user != nil && user.Type == models.Panel:
+		return "layouts/panel"
+	case strings.HasPrefix(path, "/auth"):
+		return "layouts/auth"
+	}
+	return "layouts/website"
+}
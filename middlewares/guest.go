@@ -3,7 +3,6 @@ package middlewares
 import (
 	"zatrano/configs/sessionconfig"
 	"zatrano/models"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,8 +13,7 @@ func GuestMiddleware(c *fiber.Ctx) error {
 		return c.Next()
 	}
 
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
+	user, err := authService().GetAuthenticatedUser(userID)
 	if err != nil {
 		sessionconfig.DestroySession(c)
 		return c.Next()
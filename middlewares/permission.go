@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+func Can(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user := auth.CurrentUser(c)
+		if user == nil {
+			return redirectUnauthenticated(c)
+		}
+
+		if auth.Can(c, permission) {
+			return c.Next()
+		}
+
+		logconfig.Log.Warn("Yetkisiz erişim denemesi",
+			zap.Uint("user_id", user.ID),
+			zap.String("permission", permission),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+		)
+
+		if auth.CurrentToken(c) != nil || apperrors.WantsJSON(c) {
+			return apperrors.Respond(c, apperrors.ErrForbidden)
+		}
+
+		redirectURL := "/panel/home"
+		if user.Type == models.Dashboard {
+			redirectURL = "/dashboard/home"
+		}
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.permission_denied")
+		return c.Redirect(redirectURL, fiber.StatusSeeOther)
+	}
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
+	"zatrano/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var apiTokenService = sync.OnceValue(services.NewAPITokenService)
+
+var errTokenRequired = apperrors.Unauthorized("token_required", "API tokenı gerekli.")
+
+// TokenAuth, "Authorization: Bearer <token>" başlığıyla gelen API isteklerini
+// doğrular. Oturum kullanılmaz; kullanıcı, AuthMiddleware ile aynı şekilde
+// context'e yazılır ve token kapsamı Can tarafından ayrıca kontrol edilir.
+func TokenAuth(c *fiber.Ctx) error {
+	header := c.Get(fiber.HeaderAuthorization)
+	scheme, plainToken, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(plainToken) == "" {
+		return tokenUnauthorized(c, errTokenRequired)
+	}
+
+	user, token, err := apiTokenService().Authenticate(c.UserContext(), strings.TrimSpace(plainToken), c.IP())
+	if err != nil {
+		if errors.Is(err, services.ErrUserInactive) {
+			return err
+		}
+		return tokenUnauthorized(c, err)
+	}
+
+	auth.SetCurrentUser(c, user)
+	auth.SetCurrentToken(c, token)
+	return c.Next()
+}
+
+func tokenUnauthorized(c *fiber.Ctx, err error) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="api"`)
+	return apperrors.Respond(c, err)
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TwoFactorSetupMiddleware, iki adımlı doğrulaması zorunlu tutulan ancak henüz
+// kurulum yapmamış kullanıcıları kurulum sayfasına yönlendirir.
+func TwoFactorSetupMiddleware(c *fiber.Ctx) error {
+	user := auth.CurrentUser(c)
+	if user == nil {
+		return redirectUnauthenticated(c)
+	}
+
+	if user.NeedsTwoFactorSetup() {
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.two_factor_setup_required")
+		return c.Redirect("/auth/2fa/setup")
+	}
+
+	return c.Next()
+}
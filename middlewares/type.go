@@ -3,31 +3,22 @@ package middlewares
 import (
 	"zatrano/configs/sessionconfig"
 	"zatrano/models"
+	"zatrano/pkg/auth"
 	"zatrano/pkg/flashmessages"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func TypeMiddleware(requiredType models.UserType) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		userID, err := sessionconfig.GetUserIDFromSession(c)
-		if err != nil || userID == 0 {
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Yetkili oturum bulunamadı")
-			return c.Redirect("/auth/login")
-		}
-
-		authService := services.NewAuthService()
-		user, err := authService.GetUserProfile(userID)
-		if err != nil {
-			sessionconfig.DestroySession(c)
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı bulunamadı")
-			return c.Redirect("/auth/login")
+		user := auth.CurrentUser(c)
+		if user == nil {
+			return redirectUnauthenticated(c)
 		}
 
 		if user.Type != requiredType {
 			sessionconfig.DestroySession(c)
-			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Bu sayfaya erişim izniniz yok")
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.page_access_denied")
 			return c.Redirect("/auth/login")
 		}
 
@@ -2,29 +2,21 @@ package middlewares
 
 import (
 	"zatrano/configs/sessionconfig"
+	"zatrano/pkg/auth"
 	"zatrano/pkg/flashmessages"
-	"zatrano/services"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func VerifiedMiddleware(c *fiber.Ctx) error {
-	userID, err := sessionconfig.GetUserIDFromSession(c)
-	if err != nil || userID == 0 {
-		return c.Redirect("/auth/login")
-	}
-
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
-	if err != nil {
-		sessionconfig.DestroySession(c)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Kullanıcı bulunamadı")
-		return c.Redirect("/auth/login")
+	user := auth.CurrentUser(c)
+	if user == nil {
+		return redirectUnauthenticated(c)
 	}
 
 	if !user.EmailVerified {
 		sessionconfig.SetSessionValue(c, "pending_verification", true)
-		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "Lütfen e-posta adresinizi doğrulayın")
+		_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, "auth.email_verification_required")
 		return c.Redirect("/auth/login")
 	}
 
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIToken, kullanıcının betik ve mobil istemciler için oluşturduğu kişisel erişim tokenıdır.
+// Düz token yalnızca oluşturulduğu anda gösterilir; veritabanında özeti tutulur.
+type APIToken struct {
+	ID         uint      `gorm:"primarykey"`
+	UserID     uint      `gorm:"not null;index"`
+	Name       string    `gorm:"size:100;not null"`
+	TokenHash  string    `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	Prefix     string    `gorm:"size:16;not null"`
+	Scopes     string    `gorm:"size:500"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	LastUsedAt *time.Time
+	LastUsedIP string `gorm:"size:45"`
+	CreatedAt  time.Time
+}
+
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// ScopeList, boşlukla ayrılmış kapsamları dilim olarak döner.
+func (t *APIToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+func (t *APIToken) HasScope(scope string) bool {
+	for _, s := range t.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *APIToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
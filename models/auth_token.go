@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type AuthTokenPurpose string
+
+const (
+	TokenPurposePasswordReset     AuthTokenPurpose = "password_reset"
+	TokenPurposeEmailVerification AuthTokenPurpose = "email_verification"
+	TokenPurposeMagicLink         AuthTokenPurpose = "magic_link"
+	TokenPurposeEmailChange       AuthTokenPurpose = "email_change"
+	TokenPurposeAccountUnlock     AuthTokenPurpose = "account_unlock"
+	TokenPurposeIdentityLink      AuthTokenPurpose = "identity_link"
+)
+
+// AuthToken, e-posta ile gönderilen tek kullanımlık bağlantıların özetini saklar.
+// Düz token hiçbir zaman veritabanına yazılmaz.
+type AuthToken struct {
+	ID        uint             `gorm:"primarykey"`
+	UserID    uint             `gorm:"not null;index"`
+	TokenHash string           `gorm:"size:64;not null;uniqueIndex"`
+	Purpose   AuthTokenPurpose `gorm:"size:32;not null;index"`
+	Payload   string           `gorm:"size:255"`
+	ExpiresAt time.Time        `gorm:"not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (AuthToken) TableName() string {
+	return "auth_tokens"
+}
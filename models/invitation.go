@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Invitation, bir panel kullanıcısının oluşturduğu dijital davetiyedir. Görsel dosya adı
+// fileconfig'in "invitation" türü altında saklanır; katılım (LCV) yanıtları bu modelin
+// kapsamında değildir.
+type Invitation struct {
+	BaseModel
+
+	UserID       uint      `gorm:"not null;index"`
+	Category     string    `gorm:"size:100;not null;index"`
+	Title        string    `gorm:"size:200;not null"`
+	Description  string    `gorm:"type:text"`
+	EventDate    time.Time `gorm:"not null;index"`
+	Location     string    `gorm:"size:255"`
+	ContactPhone string    `gorm:"size:20"`
+	OnlineLink   string    `gorm:"size:500"`
+	Image        string    `gorm:"size:255"`
+}
+
+func (Invitation) TableName() string {
+	return "invitations"
+}
@@ -0,0 +1,21 @@
+package models
+
+// All, uygulamanın sahip olduğu tüm tabloların modellerini döner. "-migrate plan" bu
+// modelleri canlı şemayla karşılaştırır; yeni bir tablo eklendiğinde buraya da eklenmelidir.
+func All() []interface{} {
+	return []interface{}{
+		&Permission{},
+		&Role{},
+		&User{},
+		&UserSession{},
+		&UserRecoveryCode{},
+		&AuthToken{},
+		&UserIdentity{},
+		&WebAuthnCredential{},
+		&APIToken{},
+		&Invitation{},
+		&Session{},
+		&LoginAttempt{},
+		&SeederRun{},
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+const (
+	PermissionUsersView   = "users.view"
+	PermissionUsersCreate = "users.create"
+	PermissionUsersUpdate = "users.update"
+	PermissionUsersDelete = "users.delete"
+	PermissionRolesManage = "roles.manage"
+	// PermissionUsersManageCredentials, başka bir kullanıcının e-posta, şifre, tip ve
+	// iki adımlı doğrulama ayarlarını değiştirmeyi sağlar.
+	PermissionUsersManageCredentials = "users.manage_credentials"
+)
+
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+)
+
+type Permission struct {
+	BaseModel
+	Name        string `gorm:"size:100;unique;not null"`
+	Description string `gorm:"size:255"`
+}
+
+type Role struct {
+	BaseModel
+	Name        string       `gorm:"size:100;unique;not null"`
+	Description string       `gorm:"size:255"`
+	Permissions []Permission `gorm:"many2many:role_permissions;"`
+}
+
+func (r *Role) HasPermission(name string) bool {
+	for _, permission := range r.Permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Session, sessionstore.PostgresStorage'ın kullandığı "sessions" tablosunun şemasıdır.
+// Satırlar yalnızca session deposu üzerinden okunup yazılır.
+type Session struct {
+	Key       string     `gorm:"column:key;primaryKey;size:128"`
+	Data      []byte     `gorm:"column:data;not null"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;index"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// LoginAttempt, attemptstore.PostgresStore'un giriş denemesi sayaçlarını tuttuğu
+// "login_attempts" tablosunun şemasıdır.
+type LoginAttempt struct {
+	Key           string    `gorm:"column:key;primaryKey;size:255"`
+	Count         int       `gorm:"not null;default:0"`
+	LastAttemptAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+	ExpiresAt     time.Time `gorm:"not null;index"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+// SeederRun, çalıştırılmış seeder'ların kaydedildiği "seeder_runs" tablosunun satırıdır.
+type SeederRun struct {
+	Name  string    `gorm:"primaryKey;size:100"`
+	RanAt time.Time `gorm:"not null"`
+}
+
+func (SeederRun) TableName() string {
+	return "seeder_runs"
+}
@@ -16,6 +16,11 @@ const (
 func (UserType) GormDataType() string {
 	return "user_type"
 }
+
+// EnumValues, user_type enum tipinin değerleridir; "-migrate plan" veritabanındaki tipi bunlarla karşılaştırır.
+func (UserType) EnumValues() []string {
+	return []string{string(Dashboard), string(Panel)}
+}
 func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 	if db.Dialector.Name() == "postgres" {
 		return "user_type"
@@ -25,16 +30,25 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 
 type User struct {
 	BaseModel
-	Name              string       `gorm:"size:100;not null;index"`
-	Email             string       `gorm:"size:100;unique;not null"`
-	Password          string       `gorm:"size:255;not null"`
-	Status            bool         `gorm:"default:true;index"`
-	Type              UserType     `gorm:"type:user_type;not null;default:'panel';index"`
-	ResetToken        string       `gorm:"size:255;index"`
-	EmailVerified     bool         `gorm:"default:false;index"`
-	VerificationToken string       `gorm:"size:255;index"`
-	Provider          string       `gorm:"size:50;index"`
-	ProviderID        string       `gorm:"size:100;index"`
+	Name              string   `gorm:"size:100;not null;index"`
+	Email             string   `gorm:"size:100;unique;not null"`
+	Password          string   `gorm:"size:255;not null" json:"-"`
+	Status            bool     `gorm:"default:true;index"`
+	Type              UserType `gorm:"type:user_type;not null;default:'panel';index"`
+	EmailVerified     bool     `gorm:"default:false;index"`
+	TwoFactorSecret   string   `gorm:"size:64" json:"-"`
+	TwoFactorEnabled  bool     `gorm:"default:false"`
+	TwoFactorRequired bool     `gorm:"default:false;index"`
+	Locale            string   `gorm:"size:10"`
+	Roles             []Role   `gorm:"many2many:user_roles;"`
+
+	// TwoFactorLastCounter, son kabul edilen TOTP adımıdır; aynı kodun tekrar kullanılmasını engeller.
+	TwoFactorLastCounter int64 `gorm:"not null;default:0" json:"-"`
+}
+
+// NeedsTwoFactorSetup, iki adımlı doğrulama zorunlu tutulduğu halde henüz kurulmamışsa true döner.
+func (u *User) NeedsTwoFactorSetup() bool {
+	return u != nil && u.TwoFactorRequired && !u.TwoFactorEnabled
 }
 
 func (u *User) CheckPassword(password string) error {
@@ -49,3 +63,27 @@ func (u *User) SetPassword(password string) error {
 	u.Password = string(hashedPassword)
 	return nil
 }
+
+func (u *User) HasPermission(name string) bool {
+	if u == nil {
+		return false
+	}
+	for i := range u.Roles {
+		if u.Roles[i].HasPermission(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *User) HasRole(name string) bool {
+	if u == nil {
+		return false
+	}
+	for _, role := range u.Roles {
+		if role.Name == name {
+			return true
+		}
+	}
+	return false
+}
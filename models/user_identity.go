@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UserIdentity, bir kullanıcıya bağlanmış harici giriş sağlayıcısı kimliğidir.
+// Subject, sağlayıcının kullanıcı için döndürdüğü değişmez kimliktir.
+type UserIdentity struct {
+	ID         uint   `gorm:"primarykey"`
+	UserID     uint   `gorm:"not null;index;uniqueIndex:idx_user_identities_user_provider"`
+	Provider   string `gorm:"size:50;not null;uniqueIndex:idx_user_identities_provider_subject;uniqueIndex:idx_user_identities_user_provider"`
+	Subject    string `gorm:"size:255;not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email      string `gorm:"size:100"`
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// UserRecoveryCode, iki adımlı doğrulama için tek kullanımlık kurtarma kodlarını
+// SHA-256 özeti olarak saklar.
+type UserRecoveryCode struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    uint   `gorm:"not null;index:idx_user_recovery_codes_user_code,priority:1"`
+	CodeHash  string `gorm:"size:64;not null;index:idx_user_recovery_codes_user_code,priority:2"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// UserSession, bir kullanıcının açık oturumlarını cihaz bilgisiyle birlikte izler.
+// SessionID, session deposundaki anahtardır ve arayüzde gösterilmez.
+type UserSession struct {
+	ID         uint   `gorm:"primarykey"`
+	SessionID  string `gorm:"size:128;uniqueIndex;not null"`
+	UserID     uint   `gorm:"index;not null"`
+	IPAddress  string `gorm:"size:45"`
+	UserAgent  string `gorm:"size:512"`
+	CreatedAt  time.Time
+	LastSeenAt time.Time `gorm:"index"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// WebAuthnCredential, kullanıcının kaydettiği bir passkey'in açık anahtarını ve imza sayacını saklar.
+type WebAuthnCredential struct {
+	ID              uint   `gorm:"primarykey"`
+	UserID          uint   `gorm:"not null;index"`
+	Name            string `gorm:"size:100;not null"`
+	CredentialID    []byte `gorm:"not null;uniqueIndex"`
+	PublicKey       []byte `gorm:"not null"`
+	AttestationType string `gorm:"size:32"`
+	Transports      string `gorm:"size:100"`
+	AAGUID          []byte
+	SignCount       uint32 `gorm:"not null;default:0"`
+	BackupEligible  bool   `gorm:"default:false"`
+	BackupState     bool   `gorm:"default:false"`
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time
+}
+
+func (WebAuthnCredential) TableName() string {
+	return "webauthn_credentials"
+}
@@ -0,0 +1,216 @@
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"zatrano/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Error, uygulama genelinde kullanılan tipli hatadır. Status HTTP yanıt kodunu,
+// Code makine tarafından okunabilir kimliği, Message kullanıcıya gösterilecek
+// metni taşır. Err yalnızca loglama içindir ve istemciye hiçbir zaman dönmez.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Fields  map[string]string
+	Err     error
+
+	customMessage bool
+}
+
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is, aynı koda sahip hataları eşit sayar; böylece Wrap veya WithFields ile
+// türetilen kopyalar errors.Is ile tanımlandıkları sabite eşleşir.
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+	return e.Code == t.Code && e.Status == t.Status
+}
+
+// Wrap, altta yatan hatayı loglanmak üzere ekleyerek hatanın bir kopyasını döner.
+func (e *Error) Wrap(err error) *Error {
+	clone := *e
+	clone.Err = err
+	return &clone
+}
+
+// WithFields, alan bazlı hataları ekleyerek hatanın bir kopyasını döner.
+func (e *Error) WithFields(fields map[string]string) *Error {
+	clone := *e
+	clone.Fields = fields
+	return &clone
+}
+
+// WithMessage, kullanıcı mesajını değiştirerek hatanın bir kopyasını döner.
+// Değiştirilen mesaj çevrilmez; çevrilebilir metinler için ayrı kodla yeni bir hata tanımlayın.
+func (e *Error) WithMessage(message string) *Error {
+	clone := *e
+	clone.Message = message
+	clone.customMessage = true
+	return &clone
+}
+
+const (
+	CodeBadRequest   = "bad_request"
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeValidation   = "validation_failed"
+	CodeInternal     = "internal_error"
+)
+
+var (
+	ErrBadRequest   = New(http.StatusBadRequest, CodeBadRequest, "Geçersiz istek.")
+	ErrUnauthorized = New(http.StatusUnauthorized, CodeUnauthorized, "Bu işlem için giriş yapmanız gerekiyor.")
+	ErrForbidden    = New(http.StatusForbidden, CodeForbidden, "Bu işlem için yetkiniz yok.")
+	ErrNotFound     = New(http.StatusNotFound, CodeNotFound, "Aradığınız sayfa bulunamadı.")
+	ErrValidation   = New(http.StatusUnprocessableEntity, CodeValidation, "Gönderilen bilgiler geçersiz.")
+	ErrInternal     = New(http.StatusInternalServerError, CodeInternal, "İşlem sırasında bir sorun oluştu. Lütfen tekrar deneyin.")
+)
+
+func BadRequest(code, message string) *Error {
+	return New(http.StatusBadRequest, code, message)
+}
+
+func Unauthorized(code, message string) *Error {
+	return New(http.StatusUnauthorized, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return New(http.StatusForbidden, code, message)
+}
+
+func NotFound(code, message string) *Error {
+	return New(http.StatusNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(http.StatusConflict, code, message)
+}
+
+func Unprocessable(code, message string) *Error {
+	return New(http.StatusUnprocessableEntity, code, message)
+}
+
+func TooManyRequests(code, message string) *Error {
+	return New(http.StatusTooManyRequests, code, message)
+}
+
+func Internal(code, message string) *Error {
+	return New(http.StatusInternalServerError, code, message)
+}
+
+func Unavailable(code, message string) *Error {
+	return New(http.StatusServiceUnavailable, code, message)
+}
+
+// Validation, alan adı → mesaj eşlemesiyle 422 doğrulama hatası üretir.
+func Validation(fields map[string]string) *Error {
+	return ErrValidation.WithFields(fields)
+}
+
+// From, herhangi bir hatayı *Error'a çevirir. Tanınmayan hatalar iç hata
+// olarak sarılır; böylece ayrıntıları kullanıcıya sızmaz.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		switch fiberErr.Code {
+		case http.StatusNotFound:
+			return ErrNotFound.Wrap(err)
+		case http.StatusForbidden:
+			return ErrForbidden.Wrap(err)
+		case http.StatusUnauthorized:
+			return ErrUnauthorized.Wrap(err)
+		}
+		if fiberErr.Code >= http.StatusInternalServerError {
+			return ErrInternal.Wrap(err)
+		}
+		return New(fiberErr.Code, codeForStatus(fiberErr.Code), fiberErr.Message)
+	}
+
+	return ErrInternal.Wrap(err)
+}
+
+// Message, hatanın kullanıcıya gösterilebilecek metnini döner.
+func Message(err error) string {
+	if appErr := From(err); appErr != nil {
+		return appErr.Message
+	}
+	return ""
+}
+
+// Key, hatanın çeviri anahtarını ("errors.<code>") döner; flash mesajı olarak kullanılır.
+// Katalogda karşılığı olmayan ya da mesajı WithMessage ile değiştirilmiş hatalarda
+// hatanın kendi mesajı döner.
+func Key(err error) string {
+	appErr := From(err)
+	if appErr == nil {
+		return ""
+	}
+	key := "errors." + appErr.Code
+	if appErr.customMessage {
+		return appErr.Message
+	}
+	if _, ok := i18n.Lookup(i18n.DefaultLocale, key); !ok {
+		return appErr.Message
+	}
+	return key
+}
+
+// Localized, hatanın mesajını isteğin diline çevirir.
+func Localized(c *fiber.Ctx, err error) string {
+	appErr := From(err)
+	if appErr == nil {
+		return ""
+	}
+	if appErr.customMessage {
+		return appErr.Message
+	}
+	if text, ok := i18n.Lookup(i18n.Locale(c), "errors."+appErr.Code); ok {
+		return text
+	}
+	return appErr.Message
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusUnprocessableEntity:
+		return CodeValidation
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
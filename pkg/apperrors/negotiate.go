@@ -0,0 +1,27 @@
+package apperrors
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WantsJSON, isteğin bir API istemcisinden geldiğini varsayar: /api altındaki
+// yollar veya Accept başlığında HTML yerine JSON'u tercih eden istekler.
+func WantsJSON(c *fiber.Ctx) bool {
+	if strings.HasPrefix(c.Path(), "/api/") {
+		return true
+	}
+	if c.Get(fiber.HeaderAccept) == "" {
+		return false
+	}
+	return c.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON, ProblemContentType) != fiber.MIMETextHTML
+}
+
+// Respond, hatayı isteğin diline çevrilmiş ayrıntıyla problem+json olarak yazar.
+func Respond(c *fiber.Ctx, err error) error {
+	appErr := From(err)
+	problem := appErr.Problem(c.OriginalURL())
+	problem.Detail = Localized(c, appErr)
+	return c.Status(appErr.Status).JSON(problem, ProblemContentType)
+}
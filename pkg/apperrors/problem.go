@@ -0,0 +1,29 @@
+package apperrors
+
+import "net/http"
+
+const ProblemContentType = "application/problem+json"
+
+// Problem, RFC 7807 "Problem Details for HTTP APIs" gövdesidir.
+// Code ve Errors alanları standarda eklenen uzantı üyeleridir.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+func (e *Error) Problem(instance string) Problem {
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(e.Status),
+		Status:   e.Status,
+		Detail:   e.Message,
+		Instance: instance,
+		Code:     e.Code,
+		Errors:   e.Fields,
+	}
+}
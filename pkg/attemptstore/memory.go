@@ -0,0 +1,91 @@
+package attemptstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepThreshold aşıldığında süresi dolmuş kayıtlar temizlenir.
+const sweepThreshold = 10000
+
+type memoryEntry struct {
+	Entry
+	expiresAt time.Time
+}
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || s.expired(entry, time.Now()) {
+		delete(s.entries, key)
+		return Entry{}, nil
+	}
+	return entry.Entry, nil
+}
+
+func (s *MemoryStore) Increment(_ context.Context, key string, window time.Duration) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if len(s.entries) > sweepThreshold {
+		s.sweep(now)
+	}
+
+	entry, ok := s.entries[key]
+	if !ok || s.expired(entry, now) {
+		entry = memoryEntry{}
+	}
+	entry.Count++
+	entry.LastAttemptAt = now
+	entry.expiresAt = now.Add(window)
+	s.entries[key] = entry
+	return entry.Entry, nil
+}
+
+func (s *MemoryStore) Lock(_ context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.entries[key]
+	entry.LockedUntil = until
+	if entry.expiresAt.Before(until) {
+		entry.expiresAt = until
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *MemoryStore) expired(entry memoryEntry, now time.Time) bool {
+	return !now.Before(entry.expiresAt) && !entry.Locked(now)
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if s.expired(entry, now) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+var _ Store = (*MemoryStore)(nil)
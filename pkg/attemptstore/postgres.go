@@ -0,0 +1,88 @@
+package attemptstore
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostgresStore, sayaçları "login_attempts" tablosunda tutar; böylece birden
+// fazla uygulama örneği aynı sayaçları paylaşır.
+type PostgresStore struct {
+	db         *gorm.DB
+	increments atomic.Uint64
+}
+
+// purgeEvery, kaç Increment çağrısında bir süresi dolmuş kayıtların silineceğini belirler.
+const purgeEvery = 100
+
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+type attemptRow struct {
+	Count         int
+	LastAttemptAt time.Time
+	LockedUntil   sql.NullTime
+}
+
+func (r attemptRow) entry() Entry {
+	entry := Entry{Count: r.Count, LastAttemptAt: r.LastAttemptAt}
+	if r.LockedUntil.Valid {
+		entry.LockedUntil = r.LockedUntil.Time
+	}
+	return entry
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (Entry, error) {
+	var rows []attemptRow
+	err := s.db.WithContext(ctx).Raw(`
+SELECT count, last_attempt_at, locked_until FROM login_attempts
+WHERE key = ? AND (expires_at > NOW() OR locked_until > NOW())`, key).Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return Entry{}, err
+	}
+	return rows[0].entry(), nil
+}
+
+func (s *PostgresStore) Increment(ctx context.Context, key string, window time.Duration) (Entry, error) {
+	var row attemptRow
+	err := s.db.WithContext(ctx).Raw(`
+INSERT INTO login_attempts (key, count, last_attempt_at, expires_at)
+VALUES (?, 1, NOW(), NOW() + make_interval(secs => ?))
+ON CONFLICT (key) DO UPDATE SET
+	count = CASE WHEN login_attempts.expires_at <= NOW() AND COALESCE(login_attempts.locked_until <= NOW(), true)
+		THEN 1 ELSE login_attempts.count + 1 END,
+	locked_until = CASE WHEN login_attempts.locked_until <= NOW() THEN NULL ELSE login_attempts.locked_until END,
+	last_attempt_at = EXCLUDED.last_attempt_at,
+	expires_at = EXCLUDED.expires_at
+RETURNING count, last_attempt_at, locked_until`, key, window.Seconds()).Scan(&row).Error
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if s.increments.Add(1)%purgeEvery == 0 {
+		s.db.WithContext(ctx).Exec(`
+DELETE FROM login_attempts
+WHERE expires_at <= NOW() AND (locked_until IS NULL OR locked_until <= NOW())`)
+	}
+	return row.entry(), nil
+}
+
+func (s *PostgresStore) Lock(ctx context.Context, key string, until time.Time) error {
+	return s.db.WithContext(ctx).Exec(`
+INSERT INTO login_attempts (key, count, last_attempt_at, locked_until, expires_at)
+VALUES (?, 0, NOW(), ?, ?)
+ON CONFLICT (key) DO UPDATE SET
+	locked_until = EXCLUDED.locked_until,
+	expires_at = GREATEST(login_attempts.expires_at, EXCLUDED.expires_at)`, key, until, until).Error
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Exec(`DELETE FROM login_attempts WHERE key = ?`, key).Error
+}
+
+var _ Store = (*PostgresStore)(nil)
@@ -0,0 +1,26 @@
+package attemptstore
+
+import (
+	"context"
+	"time"
+)
+
+// Entry, bir anahtar (e-posta veya IP) için tutulan başarısız deneme bilgisidir.
+type Entry struct {
+	Count         int
+	LastAttemptAt time.Time
+	LockedUntil   time.Time
+}
+
+func (e Entry) Locked(now time.Time) bool {
+	return !e.LockedUntil.IsZero() && now.Before(e.LockedUntil)
+}
+
+// Store, başarısız giriş sayaçlarının saklandığı depodur. Süresi dolan
+// kayıtlar sıfırlanmış kabul edilir.
+type Store interface {
+	Get(ctx context.Context, key string) (Entry, error)
+	Increment(ctx context.Context, key string, window time.Duration) (Entry, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	userLocalsKey      = "user"
+	userIDLocalsKey    = "userID"
+	userTypeLocalsKey  = "userType"
+	userEmailLocalsKey = "userEmail"
+	tokenLocalsKey     = "apiToken"
+)
+
+type contextKey string
+
+const (
+	userContextKey  contextKey = "auth_user"
+	tokenContextKey contextKey = "auth_token"
+)
+
+// SetCurrentUser, kimliği doğrulanmış kullanıcıyı istek boyunca kullanılmak
+// üzere c.Locals ve kullanıcı context'ine yazar.
+func SetCurrentUser(c *fiber.Ctx, user *models.User) {
+	ctx := context.WithValue(c.UserContext(), "user_id", user.ID)
+	ctx = context.WithValue(ctx, "user_type", user.Type)
+	ctx = context.WithValue(ctx, "user_email", user.Email)
+	ctx = context.WithValue(ctx, userContextKey, user)
+	c.SetUserContext(ctx)
+
+	c.Locals(userLocalsKey, user)
+	c.Locals(userIDLocalsKey, user.ID)
+	c.Locals(userTypeLocalsKey, user.Type)
+	c.Locals(userEmailLocalsKey, user.Email)
+}
+
+// CurrentUser, AuthMiddleware tarafından yüklenen kullanıcıyı döner.
+// Kimliği doğrulanmamış isteklerde nil döner.
+func CurrentUser(c *fiber.Ctx) *models.User {
+	user, _ := c.Locals(userLocalsKey).(*models.User)
+	return user
+}
+
+func CurrentUserID(c *fiber.Ctx) uint {
+	if user := CurrentUser(c); user != nil {
+		return user.ID
+	}
+	return 0
+}
+
+func UserFromContext(ctx context.Context) *models.User {
+	user, _ := ctx.Value(userContextKey).(*models.User)
+	return user
+}
+
+// SetCurrentToken, isteğin bir API tokenı ile doğrulandığını işaretler.
+func SetCurrentToken(c *fiber.Ctx, token *models.APIToken) {
+	c.SetUserContext(context.WithValue(c.UserContext(), tokenContextKey, token))
+	c.Locals(tokenLocalsKey, token)
+}
+
+// CurrentToken, istek oturumla doğrulandıysa nil döner.
+func CurrentToken(c *fiber.Ctx) *models.APIToken {
+	token, _ := c.Locals(tokenLocalsKey).(*models.APIToken)
+	return token
+}
+
+// Can, oturumdaki kullanıcının yetkiye sahip olup olmadığını döner; API tokenı
+// ile gelen isteklerde yetkinin token kapsamında olması da gerekir.
+func Can(c *fiber.Ctx, permission string) bool {
+	user := CurrentUser(c)
+	return user != nil && user.HasPermission(permission) && TokenAllows(c, permission)
+}
+
+// CanContext, Can'in servis katmanında kullanılan karşılığıdır; kullanıcıyı ve API
+// tokenını SetCurrentUser/SetCurrentToken ile yazılmış context'ten okur.
+func CanContext(ctx context.Context, permission string) bool {
+	user := UserFromContext(ctx)
+	if user == nil || !user.HasPermission(permission) {
+		return false
+	}
+	token, _ := ctx.Value(tokenContextKey).(*models.APIToken)
+	return token == nil || token.HasScope(permission)
+}
+
+// TokenAllows, API tokenı ile gelen isteklerde yetkinin token kapsamında
+// olup olmadığını kontrol eder. Oturumla gelen isteklerde her zaman true döner.
+func TokenAllows(c *fiber.Ctx, permission string) bool {
+	token := CurrentToken(c)
+	return token == nil || token.HasScope(permission)
+}
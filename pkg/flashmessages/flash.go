@@ -1,10 +1,13 @@
 package flashmessages
 
 import (
+	"fmt"
+
 	"zatrano/configs/logconfig"
 	"zatrano/configs/sessionconfig"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
 	"go.uber.org/zap"
 )
 
@@ -22,20 +25,37 @@ const (
 const (
 	FlashSuccessKey = "flash_success_message"
 	FlashErrorKey   = "flash_error_message"
+
+	flashArgsSuffix = "_args"
 )
 
+// FlashMessagesData, mesajları çeviri anahtarı olarak taşır; Args anahtardaki
+// yer tutucuların değerleridir. Çeviri, isteğin diliyle render sırasında yapılır.
 type FlashMessagesData struct {
-	Success string
-	Error   string
+	Success     string
+	SuccessArgs []interface{}
+	Error       string
+	ErrorArgs   []interface{}
 }
 
-func SetFlashMessage(c *fiber.Ctx, key string, message string) error {
+// SetFlashMessage, message olarak bir çeviri anahtarı bekler (ör. "users.created").
+// args, anahtardaki yer tutucular için ad/değer çiftleridir: "name", token.Name.
+func SetFlashMessage(c *fiber.Ctx, key string, message string, args ...interface{}) error {
 	sess, err := sessionconfig.SessionStart(c)
 	if err != nil {
 		logconfig.Log.Error("Flash mesajı için session başlatılamadı", zap.Error(err))
 		return ErrSessionStartFailed
 	}
 	sess.Set(key, message)
+	if len(args) > 0 {
+		params := make(map[string]string, len(args)/2)
+		for i := 0; i+1 < len(args); i += 2 {
+			params[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+		}
+		sess.Set(key+flashArgsSuffix, params)
+	} else {
+		sess.Delete(key + flashArgsSuffix)
+	}
 	if err := sess.Save(); err != nil {
 		logconfig.Log.Error("Flash mesajı için session kaydedilemedi", zap.Error(err))
 		return ErrSessionSaveFailed
@@ -56,6 +76,7 @@ func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 	if success := sess.Get(FlashSuccessKey); success != nil {
 		if msg, ok := success.(string); ok {
 			messages.Success = msg
+			messages.SuccessArgs = popFlashArgs(sess, FlashSuccessKey)
 			sess.Delete(FlashSuccessKey)
 			sessionNeedsSave = true
 		}
@@ -64,6 +85,7 @@ func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 	if errorFlash := sess.Get(FlashErrorKey); errorFlash != nil {
 		if msg, ok := errorFlash.(string); ok {
 			messages.Error = msg
+			messages.ErrorArgs = popFlashArgs(sess, FlashErrorKey)
 			sess.Delete(FlashErrorKey)
 			sessionNeedsSave = true
 		}
@@ -78,3 +100,17 @@ func GetFlashMessages(c *fiber.Ctx) (FlashMessagesData, error) {
 
 	return messages, nil
 }
+
+func popFlashArgs(sess *session.Session, key string) []interface{} {
+	params, ok := sess.Get(key + flashArgsSuffix).(map[string]string)
+	if !ok {
+		return nil
+	}
+	sess.Delete(key + flashArgsSuffix)
+
+	args := make([]interface{}, 0, len(params)*2)
+	for name, value := range params {
+		args = append(args, name, value)
+	}
+	return args
+}
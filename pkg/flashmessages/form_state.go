@@ -0,0 +1,78 @@
+package flashmessages
+
+import (
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	FlashFieldErrorsKey = "flash_field_errors"
+	FlashOldInputKey    = "flash_old_input"
+)
+
+// FormState, doğrulama hatasıyla geri yönlendirilen formun alan hatalarını ve
+// kullanıcının girdiği değerleri bir sonraki isteğe taşır. Anahtarlar istek
+// struct'ındaki alan adlarıdır (ör. "Email").
+type FormState struct {
+	FieldErrors map[string]string
+	OldInput    map[string][]string
+}
+
+func SetFormState(c *fiber.Ctx, state FormState) error {
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		logconfig.Log.Error("Form durumu için session başlatılamadı", zap.Error(err))
+		return ErrSessionStartFailed
+	}
+	if len(state.FieldErrors) > 0 {
+		sess.Set(FlashFieldErrorsKey, state.FieldErrors)
+	}
+	if state.OldInput != nil {
+		sess.Set(FlashOldInputKey, state.OldInput)
+	}
+	if err := sess.Save(); err != nil {
+		logconfig.Log.Error("Form durumu için session kaydedilemedi", zap.Error(err))
+		return ErrSessionSaveFailed
+	}
+	return nil
+}
+
+// GetFormState, flash mesajları gibi tek seferliktir; okunan değerler session'dan silinir.
+func GetFormState(c *fiber.Ctx) (FormState, error) {
+	state := FormState{}
+	sess, err := sessionconfig.SessionStart(c)
+	if err != nil {
+		logconfig.Log.Error("Form durumu alınırken session başlatılamadı", zap.Error(err))
+		return state, ErrSessionStartFailed
+	}
+
+	var sessionNeedsSave bool
+
+	if raw := sess.Get(FlashFieldErrorsKey); raw != nil {
+		if fieldErrors, ok := raw.(map[string]string); ok {
+			state.FieldErrors = fieldErrors
+		}
+		sess.Delete(FlashFieldErrorsKey)
+		sessionNeedsSave = true
+	}
+
+	if raw := sess.Get(FlashOldInputKey); raw != nil {
+		if oldInput, ok := raw.(map[string][]string); ok {
+			state.OldInput = oldInput
+		}
+		sess.Delete(FlashOldInputKey)
+		sessionNeedsSave = true
+	}
+
+	if sessionNeedsSave {
+		if err := sess.Save(); err != nil {
+			logconfig.Log.Error("Form durumu alındıktan sonra session kaydedilemedi", zap.Error(err))
+			return state, ErrSessionSaveFailed
+		}
+	}
+
+	return state, nil
+}
@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type formats struct {
+	date         string
+	dateTime     string
+	months       [12]string
+	longDate     string
+	thousandsSep string
+	decimalSep   string
+}
+
+var localeFormats = map[string]formats{
+	LocaleTR: {
+		date:         "02.01.2006",
+		dateTime:     "02.01.2006 15:04",
+		months:       [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+		longDate:     "{day} {month} {year}",
+		thousandsSep: ".",
+		decimalSep:   ",",
+	},
+	LocaleEN: {
+		date:         "01/02/2006",
+		dateTime:     "01/02/2006 15:04",
+		months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		longDate:     "{month} {day}, {year}",
+		thousandsSep: ",",
+		decimalSep:   ".",
+	},
+}
+
+func formatsFor(locale string) formats {
+	if f, ok := localeFormats[locale]; ok {
+		return f
+	}
+	return localeFormats[DefaultLocale]
+}
+
+func FormatDate(locale string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(formatsFor(locale).date)
+}
+
+func FormatDateTime(locale string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(formatsFor(locale).dateTime)
+}
+
+// FormatLongDate, ay adını yazıyla verir: "2 Ocak 2006" / "January 2, 2006".
+func FormatLongDate(locale string, t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	f := formatsFor(locale)
+	return strings.NewReplacer(
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", f.months[t.Month()-1],
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(f.longDate)
+}
+
+// FormatNumber, sayıyı dilin binlik ve ondalık ayraçlarıyla, verilen ondalık hane sayısında yazar.
+func FormatNumber(locale string, value float64, decimals int) string {
+	f := formatsFor(locale)
+	raw := strconv.FormatFloat(value, 'f', decimals, 64)
+
+	sign := ""
+	if strings.HasPrefix(raw, "-") {
+		sign, raw = "-", raw[1:]
+	}
+	integer, fraction, _ := strings.Cut(raw, ".")
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(f.thousandsSep)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(f.decimalSep)
+		b.WriteString(fraction)
+	}
+	return sign + b.String()
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"zatrano/configs/envconfig"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// Katalog dosyaları düz anahtarlıdır: değer ya bir metin ya da {"one": "...", "other": "..."}
+// biçiminde çoğul biçimlerdir. Metinlerdeki {ad} yer tutucuları T'ye verilen
+// ad/değer çiftleriyle doldurulur.
+type entry struct {
+	text   string
+	plural map[string]string
+}
+
+func (e *entry) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &e.plural)
+}
+
+const (
+	LocaleTR = "tr"
+	LocaleEN = "en"
+)
+
+var (
+	catalogs         = mustLoadCatalogs()
+	SupportedLocales = []string{LocaleTR, LocaleEN}
+	DefaultLocale    = LocaleTR
+)
+
+// Init, varsayılan dili APP_LOCALE ortam değişkeninden okur; .env yüklendikten sonra çağrılmalıdır.
+func Init() {
+	if locale, ok := Normalize(envconfig.GetEnvWithDefault("APP_LOCALE", LocaleTR)); ok {
+		DefaultLocale = locale
+	}
+}
+
+func mustLoadCatalogs() map[string]map[string]entry {
+	files, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: katalog dizini okunamadı: %v", err))
+	}
+
+	loaded := make(map[string]map[string]entry, len(files))
+	for _, file := range files {
+		data, err := localeFiles.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %s okunamadı: %v", file.Name(), err))
+		}
+		var catalog map[string]entry
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: %s çözümlenemedi: %v", file.Name(), err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), ".json")] = catalog
+	}
+	return loaded
+}
+
+// Normalize, "en-US" veya "TR" gibi değerleri desteklenen yerel ayar koduna çevirir.
+func Normalize(locale string) (string, bool) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	locale, _, _ = strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	for _, supported := range SupportedLocales {
+		if locale == supported {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// Lookup, anahtarın çevirisini döner. Anahtar istenen dilde yoksa varsayılan dile
+// bakılır; orada da yoksa ikinci dönüş değeri false olur.
+func Lookup(locale, key string, args ...interface{}) (string, bool) {
+	e, ok := catalogs[locale][key]
+	if !ok {
+		e, ok = catalogs[DefaultLocale][key]
+		if !ok {
+			return "", false
+		}
+	}
+
+	params := make(map[string]string, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		if name, ok := args[i].(string); ok {
+			params[name] = fmt.Sprint(args[i+1])
+		}
+	}
+
+	text := e.text
+	if e.plural != nil {
+		text = e.plural[pluralForm(locale, params["count"])]
+		if text == "" {
+			text = e.plural["other"]
+		}
+	}
+
+	for name, value := range params {
+		text = strings.ReplaceAll(text, "{"+name+"}", value)
+	}
+	return text, true
+}
+
+// T, anahtarın çevirisini döner. Katalogda bulunmayan anahtarlar olduğu gibi döner;
+// böylece henüz anahtara çevrilmemiş metinler de gösterilebilir.
+func T(locale, key string, args ...interface{}) string {
+	if text, ok := Lookup(locale, key, args...); ok {
+		return text
+	}
+	return key
+}
+
+// pluralForm, CLDR kurallarının bu iki dil için gereken kısmını uygular: tam 1 "one",
+// diğer her sayı "other" biçimini kullanır.
+func pluralForm(_ string, count string) string {
+	if count == "1" {
+		return "one"
+	}
+	return "other"
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/pkg/auth"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const LocaleCookie = "locale"
+
+// Locale, isteğin dilini sırasıyla oturumdaki kullanıcının tercihinden, locale
+// çerezinden ve Accept-Language başlığından belirler. Kullanıcı tercihi yalnızca
+// AuthMiddleware'den sonra görülebildiği için sonuç önbelleğe alınmaz.
+func Locale(c *fiber.Ctx) string {
+	if c == nil {
+		return DefaultLocale
+	}
+	if user := auth.CurrentUser(c); user != nil {
+		if locale, ok := Normalize(user.Locale); ok {
+			return locale
+		}
+	}
+	if locale, ok := Normalize(c.Cookies(LocaleCookie)); ok {
+		return locale
+	}
+	if c.Get(fiber.HeaderAcceptLanguage) != "" {
+		if locale := c.AcceptsLanguages(SupportedLocales...); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Translate, T'nin isteğin diliyle çağrılan kısayoludur.
+func Translate(c *fiber.Ctx, key string, args ...interface{}) string {
+	return T(Locale(c), key, args...)
+}
+
+func SetLocaleCookie(c *fiber.Ctx, locale string) {
+	c.Cookie(&fiber.Cookie{
+		Name:     LocaleCookie,
+		Value:    locale,
+		Path:     "/",
+		Expires:  time.Now().AddDate(1, 0, 0),
+		HTTPOnly: true,
+		Secure:   envconfig.IsProduction(),
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
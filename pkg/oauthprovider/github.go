@@ -0,0 +1,82 @@
+package oauthprovider
+
+import (
+	"context"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+const (
+	githubUserURL   = "https://api.github.com/user"
+	githubEmailsURL = "https://api.github.com/user/emails"
+)
+
+type GitHub struct {
+	cfg    Config
+	oauth2 *oauth2.Config
+}
+
+func NewGitHub(cfg Config) *GitHub {
+	return &GitHub{
+		cfg:    cfg,
+		oauth2: cfg.oauth2Config(github.Endpoint, []string{"read:user", "user:email"}),
+	}
+}
+
+func (p *GitHub) Name() string        { return "github" }
+func (p *GitHub) DisplayName() string { return "GitHub" }
+
+func (p *GitHub) AuthCodeURL(state, _ string) string {
+	return p.oauth2.AuthCodeURL(state)
+}
+
+func (p *GitHub) Exchange(ctx context.Context, code, _ string) (*Identity, error) {
+	ctx = p.cfg.context(ctx)
+	token, err := p.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := fetchJSON(ctx, p.oauth2, token, githubUserURL, &user); err != nil {
+		return nil, err
+	}
+
+	// Profildeki e-posta gizli olabilir; doğrulanmış birincil adres ayrıca sorgulanır.
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := fetchJSON(ctx, p.oauth2, token, githubEmailsURL, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		Provider: p.Name(),
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Name:     user.Name,
+	}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = email.Verified
+			break
+		}
+	}
+	if identity.Email == "" {
+		return nil, ErrMissingEmail
+	}
+	return identity, nil
+}
+
+var _ Provider = (*GitHub)(nil)
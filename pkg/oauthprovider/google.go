@@ -0,0 +1,63 @@
+package oauthprovider
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const googleUserInfoURL = "https://www.googleapis.com/oauth2/v2/userinfo"
+
+type Google struct {
+	cfg    Config
+	oauth2 *oauth2.Config
+}
+
+func NewGoogle(cfg Config) *Google {
+	return &Google{
+		cfg: cfg,
+		oauth2: cfg.oauth2Config(google.Endpoint, []string{
+			"https://www.googleapis.com/auth/userinfo.email",
+			"https://www.googleapis.com/auth/userinfo.profile",
+		}),
+	}
+}
+
+func (p *Google) Name() string        { return "google" }
+func (p *Google) DisplayName() string { return "Google" }
+
+func (p *Google) AuthCodeURL(state, _ string) string {
+	return p.oauth2.AuthCodeURL(state)
+}
+
+func (p *Google) Exchange(ctx context.Context, code, _ string) (*Identity, error) {
+	ctx = p.cfg.context(ctx)
+	token, err := p.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var userInfo struct {
+		ID            string `json:"id"`
+		Email         string `json:"email"`
+		VerifiedEmail bool   `json:"verified_email"`
+		Name          string `json:"name"`
+	}
+	if err := fetchJSON(ctx, p.oauth2, token, googleUserInfoURL, &userInfo); err != nil {
+		return nil, err
+	}
+	if userInfo.Email == "" {
+		return nil, ErrMissingEmail
+	}
+
+	return &Identity{
+		Provider:      p.Name(),
+		Subject:       userInfo.ID,
+		Email:         userInfo.Email,
+		EmailVerified: userInfo.VerifiedEmail,
+		Name:          userInfo.Name,
+	}, nil
+}
+
+var _ Provider = (*Google)(nil)
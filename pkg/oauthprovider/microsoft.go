@@ -0,0 +1,70 @@
+package oauthprovider
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/microsoft"
+)
+
+const microsoftGraphMeURL = "https://graph.microsoft.com/v1.0/me"
+
+type Microsoft struct {
+	cfg    Config
+	oauth2 *oauth2.Config
+}
+
+// NewMicrosoft, verilen tenant (common, organizations, consumers veya tenant ID)
+// için Microsoft kimlik platformunu kullanır.
+func NewMicrosoft(cfg Config, tenant string) *Microsoft {
+	if tenant == "" {
+		tenant = "common"
+	}
+	return &Microsoft{
+		cfg:    cfg,
+		oauth2: cfg.oauth2Config(microsoft.AzureADEndpoint(tenant), []string{"openid", "email", "profile", "User.Read"}),
+	}
+}
+
+func (p *Microsoft) Name() string        { return "microsoft" }
+func (p *Microsoft) DisplayName() string { return "Microsoft" }
+
+func (p *Microsoft) AuthCodeURL(state, _ string) string {
+	return p.oauth2.AuthCodeURL(state)
+}
+
+func (p *Microsoft) Exchange(ctx context.Context, code, _ string) (*Identity, error) {
+	ctx = p.cfg.context(ctx)
+	token, err := p.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var me struct {
+		ID                string `json:"id"`
+		DisplayName       string `json:"displayName"`
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+	}
+	if err := fetchJSON(ctx, p.oauth2, token, microsoftGraphMeURL, &me); err != nil {
+		return nil, err
+	}
+
+	email := me.Mail
+	if email == "" {
+		email = me.UserPrincipalName
+	}
+	if email == "" {
+		return nil, ErrMissingEmail
+	}
+
+	// Graph, adresin sahiplik doğrulamasını garanti etmez; bu yüzden doğrulanmamış kabul edilir.
+	return &Identity{
+		Provider: p.Name(),
+		Subject:  me.ID,
+		Email:    email,
+		Name:     me.DisplayName,
+	}, nil
+}
+
+var _ Provider = (*Microsoft)(nil)
@@ -0,0 +1,112 @@
+package oauthprovider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDC, discovery belgesi üzerinden yapılandırılan genel bir OpenID Connect sağlayıcısıdır.
+// Discovery ilk kullanımda yapılır; böylece uygulama açılışı sağlayıcıya bağımlı olmaz.
+type OIDC struct {
+	name        string
+	displayName string
+	issuerURL   string
+	cfg         Config
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewOIDC(name, displayName, issuerURL string, cfg Config) *OIDC {
+	if displayName == "" {
+		displayName = name
+	}
+	return &OIDC{
+		name:        name,
+		displayName: displayName,
+		issuerURL:   issuerURL,
+		cfg:         cfg,
+	}
+}
+
+func (p *OIDC) Name() string        { return p.name }
+func (p *OIDC) DisplayName() string { return p.displayName }
+
+func (p *OIDC) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(oidc.ClientContext(ctx, p.cfg.HTTPClient), p.issuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oidc discovery başarısız (%s): %w", p.issuerURL, err)
+	}
+
+	p.oauth2 = p.cfg.oauth2Config(provider.Endpoint(), []string{oidc.ScopeOpenID, "email", "profile"})
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth2, p.verifier, nil
+}
+
+// AuthCodeURL, discovery başarısız olursa boş döner; çağıran taraf bunu hata olarak ele almalıdır.
+func (p *OIDC) AuthCodeURL(state, nonce string) string {
+	cfg, _, err := p.discover(context.Background())
+	if err != nil {
+		return ""
+	}
+	return cfg.AuthCodeURL(state, oidc.Nonce(nonce))
+}
+
+func (p *OIDC) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	ctx = p.cfg.context(ctx)
+	cfg, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrInvalidIDToken
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce eşleşmiyor", ErrInvalidIDToken)
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	if claims.Email == "" {
+		return nil, ErrMissingEmail
+	}
+
+	return &Identity{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+var _ Provider = (*OIDC)(nil)
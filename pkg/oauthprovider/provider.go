@@ -0,0 +1,105 @@
+package oauthprovider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrProviderNotFound = errors.New("oauth sağlayıcısı bulunamadı")
+	ErrMissingEmail     = errors.New("sağlayıcı e-posta adresi döndürmedi")
+	ErrInvalidIDToken   = errors.New("id token doğrulanamadı")
+)
+
+// Identity, sağlayıcıdan bağımsız olarak normalize edilmiş kullanıcı kimliğidir.
+type Identity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type Provider interface {
+	Name() string
+	DisplayName() string
+	// AuthCodeURL, kullanıcının yönlendirileceği yetkilendirme adresini döner.
+	// nonce yalnızca OIDC sağlayıcılarında ID token doğrulamasında kullanılır.
+	AuthCodeURL(state, nonce string) string
+	Exchange(ctx context.Context, code, nonce string) (*Identity, error)
+}
+
+// Config, her sağlayıcı için ortak ayarları taşır. HTTPClient verilirse
+// token değişimi, discovery ve kullanıcı bilgisi istekleri bu istemciyle yapılır.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+}
+
+func (c Config) oauth2Config(endpoint oauth2.Endpoint, defaultScopes []string) *oauth2.Config {
+	scopes := c.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		RedirectURL:  c.RedirectURL,
+		Scopes:       scopes,
+		Endpoint:     endpoint,
+	}
+}
+
+func (c Config) context(ctx context.Context) context.Context {
+	if c.HTTPClient == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, c.HTTPClient)
+}
+
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Provider)}
+}
+
+func (r *Registry) Register(provider Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[provider.Name()] = provider
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+	return provider, nil
+}
+
+// All, kayıtlı sağlayıcıları ada göre sıralı döner.
+func (r *Registry) All() []Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	providers := make([]Provider, 0, len(r.providers))
+	for _, provider := range r.providers {
+		providers = append(providers, provider)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name() < providers[j].Name()
+	})
+	return providers
+}
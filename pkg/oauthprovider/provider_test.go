@@ -0,0 +1,359 @@
+package oauthprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID = "zatrano-test"
+	testKeyID    = "test-key"
+	testNonce    = "nonce-123"
+)
+
+// fakeOIDCServer, discovery, JWKS ve token uç noktalarını sunan yerel bir OIDC sağlayıcısıdır.
+// idTokenClaims her token isteğinde imzalanıp id_token olarak döner.
+type fakeOIDCServer struct {
+	*httptest.Server
+	key           *rsa.PrivateKey
+	signingKey    *rsa.PrivateKey
+	idTokenClaims map[string]any
+}
+
+func newFakeOIDCServer(t *testing.T) *fakeOIDCServer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa anahtarı üretilemedi: %v", err)
+	}
+	s := &fakeOIDCServer{key: key, signingKey: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                s.URL,
+			"authorization_endpoint":                s.URL + "/authorize",
+			"token_endpoint":                        s.URL + "/token",
+			"jwks_uri":                              s.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": testKeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		writeJSON(w, map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     s.sign(t),
+		})
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeOIDCServer) claims(overrides map[string]any) map[string]any {
+	now := time.Now()
+	claims := map[string]any{
+		"iss":            s.URL,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          testNonce,
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "Test Kullanıcı",
+	}
+	for key, value := range overrides {
+		if value == nil {
+			delete(claims, key)
+			continue
+		}
+		claims[key] = value
+	}
+	return claims
+}
+
+// sign, idTokenClaims'i RS256 ile imzalanmış kompakt bir JWT'ye dönüştürür.
+func (s *fakeOIDCServer) sign(t *testing.T) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": testKeyID})
+	payload, err := json.Marshal(s.idTokenClaims)
+	if err != nil {
+		t.Errorf("claim'ler kodlanamadı: %v", err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.signingKey, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Errorf("id token imzalanamadı: %v", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestOIDCExchange(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa anahtarı üretilemedi: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		code       string
+		nonce      string
+		overrides  map[string]any
+		signingKey *rsa.PrivateKey
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{name: "geçerli token", code: "valid-code", nonce: testNonce},
+		{name: "nonce eşleşmiyor", code: "valid-code", nonce: "another-nonce", wantErr: ErrInvalidIDToken},
+		{name: "nonce yok", code: "valid-code", nonce: testNonce, overrides: map[string]any{"nonce": nil}, wantErr: ErrInvalidIDToken},
+		{name: "yanlış imza", code: "valid-code", nonce: testNonce, signingKey: otherKey, wantErr: ErrInvalidIDToken},
+		{name: "yanlış audience", code: "valid-code", nonce: testNonce, overrides: map[string]any{"aud": "someone-else"}, wantErr: ErrInvalidIDToken},
+		{name: "yanlış issuer", code: "valid-code", nonce: testNonce, overrides: map[string]any{"iss": "https://evil.example"}, wantErr: ErrInvalidIDToken},
+		{name: "süresi dolmuş", code: "valid-code", nonce: testNonce, overrides: map[string]any{"exp": time.Now().Add(-time.Hour).Unix()}, wantErr: ErrInvalidIDToken},
+		{name: "e-posta yok", code: "valid-code", nonce: testNonce, overrides: map[string]any{"email": nil}, wantErr: ErrMissingEmail},
+		{name: "geçersiz kod", code: "bad-code", nonce: testNonce, wantAnyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeOIDCServer(t)
+			server.idTokenClaims = server.claims(tt.overrides)
+			if tt.signingKey != nil {
+				server.signingKey = tt.signingKey
+			}
+
+			provider := NewOIDC("test", "Test", server.URL, Config{
+				ClientID:     testClientID,
+				ClientSecret: "secret",
+				RedirectURL:  "http://localhost/callback",
+				HTTPClient:   server.Client(),
+			})
+			identity, err := provider.Exchange(context.Background(), tt.code, tt.nonce)
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+				}
+			case tt.wantAnyErr:
+				if err == nil {
+					t.Fatal("hata bekleniyordu")
+				}
+			default:
+				if err != nil {
+					t.Fatalf("beklenmeyen hata: %v", err)
+				}
+				want := Identity{Provider: "test", Subject: "subject-1", Email: "user@example.com", EmailVerified: true, Name: "Test Kullanıcı"}
+				if *identity != want {
+					t.Fatalf("kimlik = %+v, beklenen %+v", *identity, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOIDCAuthCodeURLIncludesNonce(t *testing.T) {
+	server := newFakeOIDCServer(t)
+	provider := NewOIDC("test", "", server.URL, Config{ClientID: testClientID, HTTPClient: server.Client()})
+
+	raw := provider.AuthCodeURL("state-1", testNonce)
+	u, err := url.Parse(raw)
+	if err != nil || !strings.HasPrefix(raw, server.URL+"/authorize") {
+		t.Fatalf("beklenmeyen yetkilendirme adresi: %q", raw)
+	}
+	if got := u.Query().Get("nonce"); got != testNonce {
+		t.Fatalf("nonce = %q, beklenen %q", got, testNonce)
+	}
+	if got := u.Query().Get("state"); got != "state-1" {
+		t.Fatalf("state = %q, beklenen state-1", got)
+	}
+}
+
+// rewriteTransport, sabit sağlayıcı adreslerine giden istekleri test sunucusuna yönlendirir.
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Original-Host", req.URL.Host)
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return t.base.RoundTrip(req)
+}
+
+func rewritingClient(t *testing.T, handler http.Handler) *http.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	target, _ := url.Parse(server.URL)
+	return &http.Client{Transport: rewriteTransport{target: target, base: server.Client().Transport}}
+}
+
+// tokenHandler, tüm sağlayıcılar için ortak token uç noktasını taklit eder.
+func tokenHandler(w http.ResponseWriter, r *http.Request) bool {
+	if !strings.HasSuffix(r.URL.Path, "/token") && !strings.HasSuffix(r.URL.Path, "/access_token") {
+		return false
+	}
+	writeJSON(w, map[string]any{"access_token": "access-token", "token_type": "Bearer"})
+	return true
+}
+
+func requireBearer(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Authorization") != "Bearer access-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func TestGitHubExchange(t *testing.T) {
+	tests := []struct {
+		name    string
+		emails  []map[string]any
+		want    *Identity
+		wantErr error
+	}{
+		{
+			name: "doğrulanmış birincil adres",
+			emails: []map[string]any{
+				{"email": "other@example.com", "primary": false, "verified": true},
+				{"email": "user@example.com", "primary": true, "verified": true},
+			},
+			want: &Identity{Provider: "github", Subject: "42", Email: "user@example.com", EmailVerified: true, Name: "octocat"},
+		},
+		{
+			name:   "doğrulanmamış birincil adres",
+			emails: []map[string]any{{"email": "user@example.com", "primary": true, "verified": false}},
+			want:   &Identity{Provider: "github", Subject: "42", Email: "user@example.com", Name: "octocat"},
+		},
+		{
+			name:    "birincil adres yok",
+			emails:  []map[string]any{{"email": "other@example.com", "primary": false, "verified": true}},
+			wantErr: ErrMissingEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := rewritingClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenHandler(w, r) || !requireBearer(w, r) {
+					return
+				}
+				switch r.URL.Path {
+				case "/user":
+					writeJSON(w, map[string]any{"id": 42, "login": "octocat", "name": ""})
+				case "/user/emails":
+					writeJSON(w, tt.emails)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			identity, err := NewGitHub(Config{ClientID: testClientID, HTTPClient: client}).Exchange(context.Background(), "code", "")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if *identity != *tt.want {
+				t.Fatalf("kimlik = %+v, beklenen %+v", *identity, *tt.want)
+			}
+		})
+	}
+}
+
+func TestMicrosoftExchange(t *testing.T) {
+	tests := []struct {
+		name    string
+		me      map[string]any
+		want    *Identity
+		wantErr error
+	}{
+		{
+			name: "mail alanı",
+			me:   map[string]any{"id": "ms-1", "displayName": "Test", "mail": "user@example.com", "userPrincipalName": "upn@example.com"},
+			want: &Identity{Provider: "microsoft", Subject: "ms-1", Email: "user@example.com", Name: "Test"},
+		},
+		{
+			name: "userPrincipalName yedeği",
+			me:   map[string]any{"id": "ms-1", "displayName": "Test", "mail": "", "userPrincipalName": "upn@example.com"},
+			want: &Identity{Provider: "microsoft", Subject: "ms-1", Email: "upn@example.com", Name: "Test"},
+		},
+		{
+			name:    "e-posta yok",
+			me:      map[string]any{"id": "ms-1", "displayName": "Test"},
+			wantErr: ErrMissingEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := rewritingClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tokenHandler(w, r) || !requireBearer(w, r) {
+					return
+				}
+				if r.Header.Get("X-Original-Host") != "graph.microsoft.com" || r.URL.Path != "/v1.0/me" {
+					http.NotFound(w, r)
+					return
+				}
+				writeJSON(w, tt.me)
+			}))
+
+			identity, err := NewMicrosoft(Config{ClientID: testClientID, HTTPClient: client}, "").Exchange(context.Background(), "code", "")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if *identity != *tt.want {
+				t.Fatalf("kimlik = %+v, beklenen %+v", *identity, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package oauthprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// fetchJSON, token ile yetkilendirilmiş bir GET isteği yapar ve yanıtı target'a çözer.
+func fetchJSON(ctx context.Context, cfg *oauth2.Config, token *oauth2.Token, url string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := cfg.Client(ctx, token).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s beklenmeyen yanıt kodu döndü: %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,276 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.1.0"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+	Tags       []Tag                `json:"tags,omitempty"`
+
+	generator *generator
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Permission  string                `json:"x-permission,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Endpoint, belgeye eklenecek bir uç noktanın tanımıdır. Query, Body ve
+// Responses alanlarına örnek değerler (ör. requests.LoginRequest{}) verilir;
+// şemalar bu değerlerin tiplerinden yansıma ile üretilir.
+type Endpoint struct {
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	Permission  string
+	Public      bool
+	Query       any
+	Body        any
+	Responses   map[int]any
+	Errors      []int
+}
+
+const (
+	MIMEJSON    = "application/json"
+	MIMEProblem = "application/problem+json"
+
+	bearerScheme = "bearerAuth"
+)
+
+// New, Bearer token güvenlik şemasıyla boş bir belge oluşturur.
+func New(title, version, description string) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version, Description: description},
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerScheme: {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "ztr_…",
+					Description:  "Profil sayfasından oluşturulan kişisel API tokenı.",
+				},
+			},
+		},
+	}
+	doc.generator = newGenerator(doc.Components.Schemas)
+	return doc
+}
+
+func (d *Document) AddTag(name, description string) {
+	d.Tags = append(d.Tags, Tag{Name: name, Description: description})
+}
+
+func (d *Document) AddServer(url, description string) {
+	d.Servers = append(d.Servers, Server{URL: url, Description: description})
+}
+
+// Register, şemaları ilk kullanımdan bağımsız olarak bileşenlere ekler.
+func (d *Document) Register(values ...any) {
+	for _, v := range values {
+		d.generator.schemaOf(v)
+	}
+}
+
+var fiberParam = regexp.MustCompile(`:(\w+)`)
+
+// Add, uç noktayı belgeye ekler. Yol fiber biçiminde (/users/:id) verilebilir;
+// yol parametreleri "id" veya "_id" ile bitiyorsa tamsayı kabul edilir.
+func (d *Document) Add(e Endpoint) {
+	path := fiberParam.ReplaceAllString(e.Path, "{$1}")
+
+	op := &Operation{
+		OperationID: e.ID,
+		Summary:     e.Summary,
+		Description: e.Description,
+		Permission:  e.Permission,
+		Responses:   map[string]*Response{},
+	}
+	if op.OperationID == "" {
+		op.OperationID = operationID(e.Method, path)
+	}
+	if e.Tag != "" {
+		op.Tags = []string{e.Tag}
+	}
+	if e.Permission != "" {
+		note := "Gerekli yetki: `" + e.Permission + "`"
+		if op.Description != "" {
+			op.Description += "\n\n" + note
+		} else {
+			op.Description = note
+		}
+	}
+	if !e.Public {
+		op.Security = []map[string][]string{{bearerScheme: {}}}
+	}
+
+	for _, match := range fiberParam.FindAllStringSubmatch(e.Path, -1) {
+		schema := &Schema{Type: "string"}
+		if match[1] == "id" || strings.HasSuffix(match[1], "_id") {
+			schema = &Schema{Type: "integer", Minimum: ptr(1.0)}
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: schema})
+	}
+	if e.Query != nil {
+		op.Parameters = append(op.Parameters, d.generator.queryParameters(e.Query)...)
+	}
+	if e.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{MIMEJSON: {Schema: d.generator.schemaOf(e.Body)}},
+		}
+	}
+
+	for status, body := range e.Responses {
+		response := &Response{Description: http.StatusText(status)}
+		if body != nil {
+			response.Content = map[string]*MediaType{MIMEJSON: {Schema: d.generator.schemaOf(body)}}
+		}
+		op.Responses[strconv.Itoa(status)] = response
+	}
+
+	errorStatuses := append([]int{}, e.Errors...)
+	if !e.Public {
+		errorStatuses = append(errorStatuses, http.StatusUnauthorized)
+	}
+	if e.Permission != "" {
+		errorStatuses = append(errorStatuses, http.StatusForbidden)
+	}
+	if len(errorStatuses) > 0 {
+		problem := d.generator.problemSchema()
+		for _, status := range errorStatuses {
+			op.Responses[strconv.Itoa(status)] = &Response{
+				Description: http.StatusText(status),
+				Content:     map[string]*MediaType{MIMEProblem: {Schema: problem}},
+			}
+		}
+	}
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	switch strings.ToUpper(e.Method) {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPatch:
+		item.Patch = op
+	case http.MethodDelete:
+		item.Delete = op
+	}
+}
+
+// JSON, belgeyi girintili ve kararlı sırayla kodlar; böylece üretilen dosya
+// incelemede satır satır karşılaştırılabilir.
+func (d *Document) JSON() ([]byte, error) {
+	sort.SliceStable(d.Tags, func(i, j int) bool { return d.Tags[i].Name < d.Tags[j].Name })
+	out, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, "{}")
+		if segment == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return b.String()
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,40 @@
+package openapi
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+
+	"zatrano/configs/logconfig"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+//go:embed docs.html
+var docsPage string
+
+// SpecHandler, belgeyi ilk istekte üretir ve sonraki isteklerde aynı çıktıyı döner.
+func SpecHandler(build func() *Document) fiber.Handler {
+	spec := sync.OnceValues(func() ([]byte, error) {
+		return build().JSON()
+	})
+	return func(c *fiber.Ctx) error {
+		body, err := spec()
+		if err != nil {
+			logconfig.Log.Error("OpenAPI belgesi üretilemedi", zap.Error(err))
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(body)
+	}
+}
+
+// DocsHandler, specURL adresindeki belgeyi gösteren Swagger UI sayfasını döner.
+func DocsHandler(specURL string) fiber.Handler {
+	page := strings.ReplaceAll(docsPage, "{{SPEC_URL}}", specURL)
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(page)
+	}
+}
@@ -0,0 +1,378 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"zatrano/pkg/apperrors"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+}
+
+// TagRule, validate etiketindeki bir kuralı şemaya yansıtır. Uygulamaya özel
+// doğrulayıcılar kendi kurallarını RegisterTagRule ile ekleyebilir.
+type TagRule func(schema *Schema, kind reflect.Kind, param string)
+
+var tagRules = map[string]TagRule{
+	"email":    func(s *Schema, _ reflect.Kind, _ string) { s.Format = "email" },
+	"url":      func(s *Schema, _ reflect.Kind, _ string) { s.Format = "uri" },
+	"uri":      func(s *Schema, _ reflect.Kind, _ string) { s.Format = "uri" },
+	"uuid":     func(s *Schema, _ reflect.Kind, _ string) { s.Format = "uuid" },
+	"numeric":  func(s *Schema, _ reflect.Kind, _ string) { s.Pattern = `^[0-9]+$` },
+	"alphanum": func(s *Schema, _ reflect.Kind, _ string) { s.Pattern = `^[a-zA-Z0-9]+$` },
+	"min":      boundRule(true),
+	"gte":      boundRule(true),
+	"max":      boundRule(false),
+	"lte":      boundRule(false),
+	"len": func(s *Schema, kind reflect.Kind, param string) {
+		boundRule(true)(s, kind, param)
+		boundRule(false)(s, kind, param)
+	},
+	"oneof": func(s *Schema, kind reflect.Kind, param string) {
+		for _, value := range strings.Fields(param) {
+			s.Enum = append(s.Enum, enumValue(kind, value))
+		}
+	},
+}
+
+func RegisterTagRule(tag string, rule TagRule) {
+	tagRules[tag] = rule
+}
+
+func boundRule(lower bool) TagRule {
+	return func(s *Schema, kind reflect.Kind, param string) {
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		switch kind {
+		case reflect.String:
+			if lower {
+				s.MinLength = ptr(int(n))
+			} else {
+				s.MaxLength = ptr(int(n))
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			if lower {
+				s.MinItems = ptr(int(n))
+			} else {
+				s.MaxItems = ptr(int(n))
+			}
+		default:
+			if lower {
+				s.Minimum = ptr(n)
+			} else {
+				s.Maximum = ptr(n)
+			}
+		}
+	}
+}
+
+func enumValue(kind reflect.Kind, value string) any {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case reflect.Bool:
+		return value == "true"
+	}
+	return value
+}
+
+// listOf ve dataOf, interface{} alan taşıyan zarf tiplerinin öğe tipini belirtir.
+type listOf struct{ envelope, item any }
+
+type dataOf struct{ item any }
+
+// ListOf, envelope tipindeki "data" alanını item dizisi olarak belgeleyen şemadır
+// (ör. ListOf(queryparams.PaginatedResult{}, UserResource{})).
+func ListOf(envelope, item any) any {
+	return listOf{envelope: envelope, item: item}
+}
+
+// DataOf, {"data": item} biçimindeki tekil yanıtları belgeler.
+func DataOf(item any) any {
+	return dataOf{item: item}
+}
+
+// knownTypes, kendi JSON kodlamasını yapan tiplerdir; yapıları şemaya yansıtılmaz.
+var knownTypes = map[string]func() *Schema{
+	"time.Time": func() *Schema { return &Schema{Type: "string", Format: "date-time"} },
+	"gorm.io/gorm.DeletedAt": func() *Schema {
+		return &Schema{Type: []string{"string", "null"}, Format: "date-time"}
+	},
+}
+
+type generator struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func newGenerator(schemas map[string]*Schema) *generator {
+	return &generator{schemas: schemas, types: map[string]reflect.Type{}}
+}
+
+func (g *generator) schemaOf(v any) *Schema {
+	switch marker := v.(type) {
+	case listOf:
+		return g.listSchema(marker)
+	case dataOf:
+		item := g.schemaOf(marker.item)
+		name := refName(item) + "Data"
+		if _, ok := g.schemas[name]; !ok {
+			g.schemas[name] = &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{"data": item},
+				Required:   []string{"data"},
+			}
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return g.schemaFor(reflect.TypeOf(v))
+}
+
+func (g *generator) listSchema(marker listOf) *Schema {
+	item := g.schemaOf(marker.item)
+	name := refName(item) + "List"
+	if _, ok := g.schemas[name]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	envelope := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	t := indirect(reflect.TypeOf(marker.envelope))
+	g.collectFields(t, envelope, fieldName)
+	envelope.Properties["data"] = &Schema{Type: "array", Items: item}
+	g.schemas[name] = envelope
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (g *generator) problemSchema() *Schema {
+	return g.schemaFor(reflect.TypeOf(apperrors.Problem{}))
+}
+
+func (g *generator) schemaFor(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if t.Kind() == reflect.Pointer {
+		return nullable(g.schemaFor(t.Elem()))
+	}
+	if build, ok := knownTypes[t.PkgPath()+"."+t.Name()]; ok {
+		return build()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: ptr(0.0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	}
+	return &Schema{}
+}
+
+func (g *generator) structRef(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		g.collectFields(t, schema, fieldName)
+		return schema
+	}
+
+	name := t.Name()
+	if existing, ok := g.types[name]; ok && existing != t {
+		name = packageName(t) + name
+	}
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := g.types[name]; ok {
+		return ref
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.types[name] = t
+	g.schemas[name] = schema
+	g.collectFields(t, schema, fieldName)
+	return ref
+}
+
+// collectFields, dışa açık alanları şemaya ekler; isimsiz gömülü yapılar
+// (ör. models.BaseModel) JSON kodlamasındaki gibi üst nesneye açılır.
+func (g *generator) collectFields(t reflect.Type, schema *Schema, name func(reflect.StructField) (string, bool)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && indirect(field.Type).Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			g.collectFields(indirect(field.Type), schema, name)
+			continue
+		}
+
+		jsonName, ok := name(field)
+		if !ok {
+			continue
+		}
+		property := g.schemaFor(field.Type)
+		if applyValidateTag(property, field) {
+			schema.Required = append(schema.Required, jsonName)
+		}
+		if doc := field.Tag.Get("doc"); doc != "" {
+			property = describe(property, doc)
+		}
+		schema.Properties[jsonName] = property
+	}
+}
+
+func (g *generator) queryParameters(v any) []Parameter {
+	t := indirect(reflect.TypeOf(v))
+	holder := &Schema{Properties: map[string]*Schema{}}
+	var order []string
+	g.collectFields(t, holder, func(field reflect.StructField) (string, bool) {
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			return "", false
+		}
+		order = append(order, name)
+		return name, true
+	})
+
+	required := make(map[string]bool, len(holder.Required))
+	for _, name := range holder.Required {
+		required[name] = true
+	}
+	params := make([]Parameter, 0, len(order))
+	for _, name := range order {
+		schema := holder.Properties[name]
+		param := Parameter{Name: name, In: "query", Required: required[name], Schema: schema}
+		if schema.Description != "" {
+			param.Description = schema.Description
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// applyValidateTag, validate etiketindeki kuralları şemaya yansıtır ve alanın
+// zorunlu olup olmadığını döner.
+func applyValidateTag(schema *Schema, field reflect.StructField) bool {
+	tag := field.Tag.Get("validate")
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	target := schema
+	if len(schema.AnyOf) > 0 {
+		target = schema.AnyOf[0]
+	}
+	kind := indirect(field.Type).Kind()
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		if rule == "dive" {
+			break
+		}
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "required" {
+			required = true
+			continue
+		}
+		if apply, ok := tagRules[name]; ok {
+			apply(target, kind, param)
+		}
+	}
+	return required
+}
+
+// fieldName, encoding/json ile aynı kuralla alan adını belirler; JSON etiketi
+// yoksa form etiketine bakılır.
+func fieldName(field reflect.StructField) (string, bool) {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return "", false
+		}
+		if name != "" {
+			return name, true
+		}
+	}
+	return field.Name, true
+}
+
+func nullable(schema *Schema) *Schema {
+	switch typ := schema.Type.(type) {
+	case string:
+		schema.Type = []string{typ, "null"}
+		return schema
+	case []string:
+		return schema
+	}
+	return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+}
+
+func describe(schema *Schema, description string) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AnyOf: []*Schema{schema}, Description: description}
+	}
+	schema.Description = description
+	return schema
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func refName(schema *Schema) string {
+	return strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+}
+
+func packageName(t reflect.Type) string {
+	path := t.PkgPath()
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		path = path[i+1:]
+	}
+	if path == "" {
+		return ""
+	}
+	return strings.ToUpper(path[:1]) + path[1:]
+}
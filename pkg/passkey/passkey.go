@@ -0,0 +1,148 @@
+// Package passkey, WebAuthn kayıt ve giriş törenlerini veritabanından ve HTTP katmanından
+// bağımsız olarak yürütür. Tören verisi ve tarayıcı yanıtı dışarıdan verildiği için
+// kaydedilmiş attestation/assertion örnekleriyle (bkz. testdata) doğrudan doğrulanabilir.
+package passkey
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+var (
+	ErrClonedAuthenticator = errors.New("imza sayacı geriledi, kimlik doğrulayıcı kopyalanmış olabilir")
+	ErrInvalidUserHandle   = errors.New("geçersiz kullanıcı tanıtıcısı")
+)
+
+type Config struct {
+	RPID          string
+	RPDisplayName string
+	RPOrigins     []string
+	Timeout       time.Duration
+}
+
+// Account, bir kullanıcının WebAuthn tarafından görülen halidir.
+type Account struct {
+	UserID      uint
+	Name        string
+	DisplayName string
+	Credentials []webauthn.Credential
+}
+
+func (a *Account) WebAuthnID() []byte                         { return UserHandle(a.UserID) }
+func (a *Account) WebAuthnName() string                       { return a.Name }
+func (a *Account) WebAuthnDisplayName() string                { return a.DisplayName }
+func (a *Account) WebAuthnIcon() string                       { return "" }
+func (a *Account) WebAuthnCredentials() []webauthn.Credential { return a.Credentials }
+
+var _ webauthn.User = (*Account)(nil)
+
+// UserHandle, kullanıcı kimliğini authenticator'a yazılan sabit tanıtıcıya çevirir.
+func UserHandle(userID uint) []byte {
+	handle := make([]byte, 8)
+	binary.BigEndian.PutUint64(handle, uint64(userID))
+	return handle
+}
+
+func ParseUserHandle(handle []byte) (uint, error) {
+	if len(handle) != 8 {
+		return 0, ErrInvalidUserHandle
+	}
+	userID := binary.BigEndian.Uint64(handle)
+	if userID == 0 {
+		return 0, ErrInvalidUserHandle
+	}
+	return uint(userID), nil
+}
+
+// AccountLookup, giriş sırasında authenticator'ın döndürdüğü tanıtıcıya ait hesabı bulur.
+type AccountLookup func(userID uint, credentialID []byte) (*Account, error)
+
+type Ceremony struct {
+	webAuthn *webauthn.WebAuthn
+}
+
+func New(cfg Config) (*Ceremony, error) {
+	timeouts := webauthn.TimeoutConfig{
+		Enforce:    true,
+		Timeout:    cfg.Timeout,
+		TimeoutUVD: cfg.Timeout,
+	}
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.RPID,
+		RPDisplayName: cfg.RPDisplayName,
+		RPOrigins:     cfg.RPOrigins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeouts,
+			Registration: timeouts,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Ceremony{webAuthn: webAuthn}, nil
+}
+
+// BeginRegistration, cihazda saklanan (discoverable) bir kimlik bilgisi ister ve hesabın
+// mevcut kimlik bilgilerini aynı authenticator'a ikinci kez kaydedilmesin diye hariç tutar.
+func (c *Ceremony) BeginRegistration(account *Account) (*protocol.CredentialCreation, *webauthn.SessionData, error) {
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(account.Credentials))
+	for _, credential := range account.Credentials {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	return c.webAuthn.BeginRegistration(account,
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			UserVerification: protocol.VerificationRequired,
+		}),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExclusions(exclusions),
+	)
+}
+
+func (c *Ceremony) FinishRegistration(account *Account, session webauthn.SessionData, body io.Reader) (*webauthn.Credential, error) {
+	parsed, err := protocol.ParseCredentialCreationResponseBody(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.webAuthn.CreateCredential(account, session, parsed)
+}
+
+// BeginLogin, kullanıcı adı sorulmadan başlayan discoverable giriş törenini başlatır.
+// Kullanıcı doğrulaması zorunlu tutulduğu için başarılı bir giriş ikinci faktör yerine geçer.
+func (c *Ceremony) BeginLogin() (*protocol.CredentialAssertion, *webauthn.SessionData, error) {
+	return c.webAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+}
+
+func (c *Ceremony) FinishLogin(session webauthn.SessionData, body io.Reader, lookup AccountLookup) (*Account, *webauthn.Credential, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBody(body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var account *Account
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		userID, err := ParseUserHandle(userHandle)
+		if err != nil {
+			return nil, err
+		}
+		account, err = lookup(userID, rawID)
+		if err != nil {
+			return nil, err
+		}
+		return account, nil
+	}
+
+	credential, err := c.webAuthn.ValidateDiscoverableLogin(handler, session, parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if credential.Authenticator.CloneWarning {
+		return account, credential, ErrClonedAuthenticator
+	}
+	return account, credential, nil
+}
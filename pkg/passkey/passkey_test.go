@@ -0,0 +1,261 @@
+package passkey
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// testdata altındaki örnekler, RP ID "localhost" ve origin "http://localhost:8080" için
+// bir yazılım authenticator'ından (P-256, "none" attestation) kaydedilmiştir.
+// Yanıtlar imzalı olduğundan challenge, origin ve sayaç değerleri değiştirilemez.
+type fixture struct {
+	Challenge    string          `json:"challenge"`
+	UserID       uint            `json:"user_id"`
+	CredentialID string          `json:"credential_id"`
+	PublicKey    string          `json:"public_key"`
+	SignCount    uint32          `json:"sign_count"`
+	Response     json.RawMessage `json:"response"`
+}
+
+func loadFixture(t *testing.T, name string) fixture {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("örnek okunamadı: %v", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(raw, &f); err != nil {
+		t.Fatalf("örnek çözülemedi: %v", err)
+	}
+	return f
+}
+
+func decodeBase64(t *testing.T, value string) []byte {
+	t.Helper()
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("base64 çözülemedi: %v", err)
+	}
+	return decoded
+}
+
+func newTestCeremony(t *testing.T) *Ceremony {
+	t.Helper()
+	ceremony, err := New(Config{
+		RPID:          "localhost",
+		RPDisplayName: "Zatrano",
+		RPOrigins:     []string{"http://localhost:8080"},
+		Timeout:       time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("tören oluşturulamadı: %v", err)
+	}
+	return ceremony
+}
+
+func registrationSession(f fixture, userID uint) webauthn.SessionData {
+	return webauthn.SessionData{
+		Challenge:        f.Challenge,
+		UserID:           UserHandle(userID),
+		UserVerification: protocol.VerificationRequired,
+		Expires:          time.Now().Add(time.Minute),
+	}
+}
+
+func TestFinishRegistration(t *testing.T) {
+	f := loadFixture(t, "registration.json")
+	ceremony := newTestCeremony(t)
+	account := &Account{UserID: f.UserID, Name: "user@example.com", DisplayName: "Test"}
+
+	credential, err := ceremony.FinishRegistration(account, registrationSession(f, f.UserID), bytes.NewReader(f.Response))
+	if err != nil {
+		t.Fatalf("kayıt doğrulanamadı: %v", err)
+	}
+	var response struct {
+		RawID string `json:"rawId"`
+	}
+	if err := json.Unmarshal(f.Response, &response); err != nil {
+		t.Fatalf("yanıt çözülemedi: %v", err)
+	}
+	if !bytes.Equal(credential.ID, decodeBase64(t, response.RawID)) {
+		t.Fatal("kimlik bilgisi ID'si yanıttaki rawId ile eşleşmiyor")
+	}
+	if len(credential.PublicKey) == 0 {
+		t.Fatal("açık anahtar boş")
+	}
+	if credential.AttestationType != "none" {
+		t.Fatalf("attestation tipi = %q, beklenen none", credential.AttestationType)
+	}
+	if credential.Authenticator.SignCount != 0 {
+		t.Fatalf("imza sayacı = %d, beklenen 0", credential.Authenticator.SignCount)
+	}
+}
+
+func TestFinishRegistrationRejectsMismatchedCeremony(t *testing.T) {
+	f := loadFixture(t, "registration.json")
+	ceremony := newTestCeremony(t)
+	account := &Account{UserID: f.UserID, Name: "user@example.com", DisplayName: "Test"}
+
+	tests := []struct {
+		name    string
+		account *Account
+		session webauthn.SessionData
+	}{
+		{
+			name:    "farklı challenge",
+			account: account,
+			session: func() webauthn.SessionData {
+				session := registrationSession(f, f.UserID)
+				session.Challenge = base64.RawURLEncoding.EncodeToString([]byte("another-challenge-another-challe"))
+				return session
+			}(),
+		},
+		{
+			name:    "başka kullanıcının töreni",
+			account: &Account{UserID: f.UserID + 1, Name: "other@example.com"},
+			session: registrationSession(f, f.UserID),
+		},
+		{
+			name:    "süresi dolmuş tören",
+			account: account,
+			session: func() webauthn.SessionData {
+				session := registrationSession(f, f.UserID)
+				session.Expires = time.Now().Add(-time.Second)
+				return session
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ceremony.FinishRegistration(tt.account, tt.session, bytes.NewReader(f.Response)); err == nil {
+				t.Fatal("kayıt reddedilmeliydi")
+			}
+		})
+	}
+}
+
+func TestFinishLogin(t *testing.T) {
+	f := loadFixture(t, "assertion.json")
+	ceremony := newTestCeremony(t)
+	credentialID := decodeBase64(t, f.CredentialID)
+	stored := webauthn.Credential{
+		ID:              credentialID,
+		PublicKey:       decodeBase64(t, f.PublicKey),
+		AttestationType: "none",
+	}
+	session := webauthn.SessionData{
+		Challenge:        f.Challenge,
+		UserVerification: protocol.VerificationRequired,
+		Expires:          time.Now().Add(time.Minute),
+	}
+
+	tests := []struct {
+		name          string
+		storedCount   uint32
+		credentials   []webauthn.Credential
+		lookupErr     error
+		wantErr       error
+		wantAnyErr    bool
+		wantSignCount uint32
+	}{
+		{name: "geçerli assertion", credentials: []webauthn.Credential{stored}, wantSignCount: f.SignCount},
+		{name: "sayaç artmış", storedCount: f.SignCount - 1, credentials: []webauthn.Credential{stored}, wantSignCount: f.SignCount},
+		{name: "sayaç aynı kalmış", storedCount: f.SignCount, credentials: []webauthn.Credential{stored}, wantErr: ErrClonedAuthenticator},
+		{name: "sayaç gerilemiş", storedCount: f.SignCount + 10, credentials: []webauthn.Credential{stored}, wantErr: ErrClonedAuthenticator},
+		{name: "kaldırılmış kimlik bilgisi", credentials: nil, wantAnyErr: true},
+		{name: "bilinmeyen kullanıcı", lookupErr: errors.New("kullanıcı bulunamadı"), wantAnyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credentials := make([]webauthn.Credential, len(tt.credentials))
+			copy(credentials, tt.credentials)
+			for i := range credentials {
+				credentials[i].Authenticator.SignCount = tt.storedCount
+			}
+
+			var lookedUp uint
+			lookup := func(userID uint, rawID []byte) (*Account, error) {
+				lookedUp = userID
+				if !bytes.Equal(rawID, credentialID) {
+					t.Errorf("lookup'a beklenmeyen kimlik bilgisi ID'si geldi")
+				}
+				if tt.lookupErr != nil {
+					return nil, tt.lookupErr
+				}
+				return &Account{UserID: userID, Name: "user@example.com", Credentials: credentials}, nil
+			}
+
+			account, credential, err := ceremony.FinishLogin(session, bytes.NewReader(f.Response), lookup)
+			if lookedUp != f.UserID {
+				t.Fatalf("lookup kullanıcı = %d, beklenen %d", lookedUp, f.UserID)
+			}
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("hata = %v, beklenen %v", err, tt.wantErr)
+				}
+			case tt.wantAnyErr:
+				if err == nil {
+					t.Fatal("giriş reddedilmeliydi")
+				}
+				if account != nil || credential != nil {
+					t.Fatal("reddedilen girişte hesap veya kimlik bilgisi dönmemeli")
+				}
+			default:
+				if err != nil {
+					t.Fatalf("giriş doğrulanamadı: %v", err)
+				}
+				if account.UserID != f.UserID {
+					t.Fatalf("hesap = %d, beklenen %d", account.UserID, f.UserID)
+				}
+				if credential.Authenticator.SignCount != tt.wantSignCount {
+					t.Fatalf("imza sayacı = %d, beklenen %d", credential.Authenticator.SignCount, tt.wantSignCount)
+				}
+				if !credential.Flags.UserVerified {
+					t.Fatal("kullanıcı doğrulaması bayrağı bekleniyordu")
+				}
+			}
+		})
+	}
+}
+
+func TestFinishLoginRejectsReplayedChallenge(t *testing.T) {
+	f := loadFixture(t, "assertion.json")
+	ceremony := newTestCeremony(t)
+	session := webauthn.SessionData{
+		Challenge:        base64.RawURLEncoding.EncodeToString([]byte("another-challenge-another-challe")),
+		UserVerification: protocol.VerificationRequired,
+		Expires:          time.Now().Add(time.Minute),
+	}
+	lookup := func(userID uint, _ []byte) (*Account, error) {
+		return &Account{UserID: userID, Credentials: []webauthn.Credential{{
+			ID:        decodeBase64(t, f.CredentialID),
+			PublicKey: decodeBase64(t, f.PublicKey),
+		}}}, nil
+	}
+
+	if _, _, err := ceremony.FinishLogin(session, bytes.NewReader(f.Response), lookup); err == nil {
+		t.Fatal("farklı challenge ile giriş reddedilmeliydi")
+	}
+}
+
+func TestParseUserHandle(t *testing.T) {
+	if id, err := ParseUserHandle(UserHandle(42)); err != nil || id != 42 {
+		t.Fatalf("ParseUserHandle = %d, %v", id, err)
+	}
+	for _, handle := range [][]byte{nil, {1, 2, 3}, UserHandle(0)} {
+		if _, err := ParseUserHandle(handle); !errors.Is(err, ErrInvalidUserHandle) {
+			t.Fatalf("%v için ErrInvalidUserHandle bekleniyordu, alınan %v", handle, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package queryparams
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	PaginationOffset = "offset"
+	PaginationCursor = "cursor"
+
+	CountNone      = "none"
+	CountExact     = "exact"
+	CountEstimated = "estimated"
+)
+
+var ErrInvalidCursor = errors.New("geçersiz cursor")
+
+// Cursor, keyset sayfalamada kalınan yeri tutar: sıralama kolonu ve yönü, son
+// satırın o kolondaki değeri ve id'si. Prev, önceki sayfaya gidildiğini belirtir.
+// İstemciye EncodeCursor ile opak bir metin olarak verilir.
+type Cursor struct {
+	SortBy  string `json:"s"`
+	OrderBy string `json:"o"`
+	Value   string `json:"v,omitempty"`
+	ID      uint   `json:"i"`
+	Prev    bool   `json:"p,omitempty"`
+}
+
+// CursorMeta, cursor sayfalamasının meta bilgisidir. TotalItems yalnızca count
+// parametresiyle istendiğinde doldurulur; TotalEstimated, sayının pg_class
+// istatistiklerinden tahmin edildiğini belirtir.
+type CursorMeta struct {
+	PerPage        int    `json:"per_page"`
+	Next           string `json:"next,omitempty"`
+	Prev           string `json:"prev,omitempty"`
+	TotalItems     *int64 `json:"total_items,omitempty"`
+	TotalEstimated bool   `json:"total_estimated,omitempty"`
+}
+
+type CursorResult struct {
+	Data interface{} `json:"data"`
+	Meta CursorMeta  `json:"meta"`
+}
+
+func EncodeCursor(c Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == 0 {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,78 @@
+package queryparams
+
+import (
+	"regexp"
+	"strings"
+)
+
+type FilterOp string
+
+const (
+	// FilterEq: filter[status][eq]=true
+	FilterEq FilterOp = "eq"
+	// FilterIn: filter[type][in]=dashboard,panel
+	FilterIn FilterOp = "in"
+	// FilterRange: filter[id][range]=10,20 (alt veya üst sınır boş bırakılabilir: 10, ya da ,20)
+	FilterRange FilterOp = "range"
+	// FilterLike: filter[name][like]=ali (Türkçe karakter ve büyük/küçük harf duyarsız)
+	FilterLike FilterOp = "like"
+	// FilterDateBetween: filter[created_at][date-between]=2026-01-01,2026-01-31 (bitiş günü dahil)
+	FilterDateBetween FilterOp = "date-between"
+	// FilterIsNull: filter[deleted_by][is-null]=true
+	FilterIsNull FilterOp = "is-null"
+)
+
+// Filters, filter[alan][operatör]=değer biçimindeki sorgu parametrelerini
+// alan ve operatöre göre tutar.
+type Filters map[string]map[FilterOp]string
+
+var filterKeyPattern = regexp.MustCompile(`^filter\[([A-Za-z0-9_]+)\]\[([a-z-]+)\]$`)
+
+// ParseFilters, sorgu parametreleri arasından filter[alan][operatör] anahtarlarını
+// toplar; değeri boş olanlar atlanır. Alan ve operatörlerin geçerliliği burada
+// değil, repository'nin izin listesinde kontrol edilir.
+func ParseFilters(query map[string]string) Filters {
+	filters := Filters{}
+	for key, value := range query {
+		match := filterKeyPattern.FindStringSubmatch(key)
+		value = strings.TrimSpace(value)
+		if match == nil || value == "" {
+			continue
+		}
+		filters.Set(match[1], FilterOp(match[2]), value)
+	}
+	return filters
+}
+
+func (f Filters) Set(field string, op FilterOp, value string) {
+	if f[field] == nil {
+		f[field] = make(map[FilterOp]string)
+	}
+	f[field][op] = value
+}
+
+// EffectiveFilters, Filters'a name, status ve type kısayollarını ekleyerek döner.
+// Kısayollar aynı alan için açıkça verilmiş bir filtreyi ezmez.
+func (p ListParams) EffectiveFilters() Filters {
+	filters := make(Filters, len(p.Filters)+3)
+	for field, ops := range p.Filters {
+		for op, value := range ops {
+			filters.Set(field, op, value)
+		}
+	}
+
+	for _, s := range []struct {
+		field string
+		op    FilterOp
+		value string
+	}{
+		{"name", FilterLike, p.Name},
+		{"status", FilterEq, p.Status},
+		{"type", FilterEq, p.Type},
+	} {
+		if s.value != "" && filters[s.field] == nil {
+			filters.Set(s.field, s.op, s.value)
+		}
+	}
+	return filters
+}
@@ -11,15 +11,24 @@ const (
 )
 
 type ListParams struct {
+	// Name, Type ve Status; filter[name][like], filter[type][eq] ve
+	// filter[status][eq] filtrelerinin kısayollarıdır.
 	Name   string `query:"name"`
 	Type   string `query:"type"`
 	Status string `query:"status"`
 
+	// Filters, QueryParser tarafından doldurulmaz; ParseFilters(c.Queries()) ile atanır.
+	Filters Filters `query:"-"`
+
 	SortBy  string `query:"sortBy"`
 	OrderBy string `query:"orderBy"`
 
 	Page    int `query:"page"`
 	PerPage int `query:"perPage"`
+
+	Pagination string `query:"pagination" doc:"offset (varsayılan) ya da cursor. cursor modunda page yok sayılır, sonraki ve önceki sayfalar meta.next/meta.prev ile istenir. cursor modunda sortBy yalnızca NULL içermeyen kolonlardan biri olabilir, diğerleri 400 döner."`
+	Cursor     string `query:"cursor" doc:"cursor modunda meta.next veya meta.prev değeri."`
+	Count      string `query:"count" doc:"cursor modunda toplam sayı: none (varsayılan), exact ya da estimated."`
 }
 
 type PaginationMeta struct {
@@ -41,6 +50,28 @@ func (p *ListParams) CalculateOffset() int {
 	return (p.Page - 1) * p.PerPage
 }
 
+// Normalize, eksik veya sınır dışı sayfalama ve sıralama değerlerini varsayılanlara çeker.
+func (p *ListParams) Normalize() {
+	if p.Page <= 0 {
+		p.Page = DefaultPage
+	}
+	if p.PerPage <= 0 || p.PerPage > MaxPerPage {
+		p.PerPage = DefaultPerPage
+	}
+	if p.SortBy == "" {
+		p.SortBy = DefaultSortBy
+	}
+	if p.OrderBy == "" {
+		p.OrderBy = DefaultOrderBy
+	}
+	if p.Pagination != PaginationCursor {
+		p.Pagination = PaginationOffset
+	}
+	if p.Count != CountExact && p.Count != CountEstimated {
+		p.Count = CountNone
+	}
+}
+
 func CalculateTotalPages(totalItems int64, perPage int) int {
 	if perPage <= 0 {
 		return 1
@@ -3,7 +3,9 @@ package renderer
 import (
 	"net/http"
 
-	"davet.link/pkg/flashmessages"
+	"zatrano/pkg/auth"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
@@ -15,18 +17,34 @@ const (
 	FlashSuccessKeyView = "Success"
 	FlashErrorKeyView   = "Error"
 	FormDataKey         = "FormData"
+	CurrentUserKey      = "CurrentUser"
+	FieldErrorsKey      = "FieldErrors"
+	OldInputKey         = "OldInput"
+	LocaleKey           = "Locale"
 )
 
 func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 	renderData := make(fiber.Map)
 
 	renderData[CsrfTokenKey] = c.Locals("csrf")
+	renderData[CurrentUserKey] = auth.CurrentUser(c)
+	locale := i18n.Locale(c)
+	renderData[LocaleKey] = locale
 
 	flashData, flashErr := flashmessages.GetFlashMessages(c)
 	if flashErr != nil {
 		log.Warn("Render helper: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
-	renderData[FlashSuccessKeyView] = flashData.Success
+	if flashData.Success != "" {
+		renderData[FlashSuccessKeyView] = i18n.T(locale, flashData.Success, flashData.SuccessArgs...)
+	}
+
+	formState, formErr := flashmessages.GetFormState(c)
+	if formErr != nil {
+		log.Warn("Render helper: Form durumu alınamadı", zap.Error(formErr))
+	}
+	renderData[FieldErrorsKey] = formState.FieldErrors
+	renderData[OldInputKey] = formState.OldInput
 
 	var handlerError string
 	if data == nil {
@@ -34,8 +52,8 @@ func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 	}
 
 	if errVal, ok := data[FlashErrorKeyView]; ok {
-		if errStr, okStr := errVal.(string); okStr {
-			handlerError = errStr
+		if errStr, okStr := errVal.(string); okStr && errStr != "" {
+			handlerError = i18n.T(locale, errStr)
 		}
 	}
 
@@ -46,8 +64,14 @@ func prepareRenderData(c *fiber.Ctx, data fiber.Map) fiber.Map {
 	for key, value := range data {
 		renderData[key] = value
 	}
+	if success, ok := data[FlashSuccessKeyView].(string); ok && success != "" {
+		renderData[FlashSuccessKeyView] = i18n.T(locale, success)
+	}
 
-	combinedError := flashData.Error
+	var combinedError string
+	if flashData.Error != "" {
+		combinedError = i18n.T(locale, flashData.Error, flashData.ErrorArgs...)
+	}
 	if handlerError != "" {
 		if combinedError != "" {
 			combinedError += " | " + handlerError
@@ -0,0 +1,129 @@
+package sessionstore
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"zatrano/configs/logconfig"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const DefaultGCInterval = 10 * time.Minute
+
+type sessionRecord struct {
+	Key       string     `gorm:"column:key;primaryKey;size:128"`
+	Data      []byte     `gorm:"column:data;not null"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;index"`
+}
+
+func (sessionRecord) TableName() string {
+	return "sessions"
+}
+
+// PostgresStorage, fiber session verilerini mevcut GORM bağlantısı üzerinden
+// "sessions" tablosunda saklar. Süresi dolan kayıtlar arka planda temizlenir.
+type PostgresStorage struct {
+	db        *gorm.DB
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewPostgresStorage(db *gorm.DB, gcInterval time.Duration) *PostgresStorage {
+	if gcInterval <= 0 {
+		gcInterval = DefaultGCInterval
+	}
+
+	s := &PostgresStorage{
+		db:   db,
+		done: make(chan struct{}),
+	}
+	go s.gcLoop(gcInterval)
+	return s
+}
+
+func (s *PostgresStorage) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	var record sessionRecord
+	err := s.db.
+		Where("key = ? AND (expires_at IS NULL OR expires_at > ?)", key, time.Now().UTC()).
+		Take(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record.Data, nil
+}
+
+func (s *PostgresStorage) Set(key string, val []byte, exp time.Duration) error {
+	if key == "" || len(val) == 0 {
+		return nil
+	}
+
+	record := sessionRecord{Key: key, Data: val}
+	if exp > 0 {
+		expiresAt := time.Now().UTC().Add(exp)
+		record.ExpiresAt = &expiresAt
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "expires_at"}),
+	}).Create(&record).Error
+}
+
+func (s *PostgresStorage) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.db.Where("key = ?", key).Delete(&sessionRecord{}).Error
+}
+
+func (s *PostgresStorage) Reset() error {
+	return s.db.Where("1 = 1").Delete(&sessionRecord{}).Error
+}
+
+func (s *PostgresStorage) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+func (s *PostgresStorage) gcLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.gc()
+		}
+	}
+}
+
+func (s *PostgresStorage) gc() {
+	result := s.db.
+		Where("expires_at IS NOT NULL AND expires_at <= ?", time.Now().UTC()).
+		Delete(&sessionRecord{})
+	if result.Error != nil {
+		logconfig.Log.Error("Süresi dolmuş oturumlar temizlenemedi", zap.Error(result.Error))
+		return
+	}
+	if result.RowsAffected > 0 {
+		logconfig.Log.Debug("Süresi dolmuş oturumlar temizlendi", zap.Int64("count", result.RowsAffected))
+	}
+}
+
+var _ fiber.Storage = (*PostgresStorage)(nil)
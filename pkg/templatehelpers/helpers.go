@@ -1,11 +1,21 @@
 package templatehelpers
 
 import (
+	"fmt"
 	"net/url"
+	"strconv"
 	"text/template"
 	"time"
+
+	"zatrano/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
 )
 
+type permissionChecker interface {
+	HasPermission(name string) bool
+}
+
 func TemplateHelpers() template.FuncMap {
 	fm := template.FuncMap{
 		"CurrentYear": func() int { return time.Now().Year() },
@@ -58,23 +68,95 @@ func TemplateHelpers() template.FuncMap {
 			return t.Format(layout)
 		},
 
-		"FormatDate": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
-			}
-			return t.Format("02.01.2006")
-		},
+		"FormatDate":     func(t time.Time) string { return i18n.FormatDate(i18n.DefaultLocale, t) },
+		"FormatDateTime": func(t time.Time) string { return i18n.FormatDateTime(i18n.DefaultLocale, t) },
 
-		"FormatDateTime": func(t time.Time) string {
-			if t.IsZero() {
-				return ""
+		// Dile duyarlı yardımcılar da form yardımcıları gibi kök veriyi alır: {{ T . "profile.language" }},
+		// {{ T $ "users.sessions_revoked" "count" 3 }}, {{ LocalDate $ .CreatedAt }}.
+		"T": func(data interface{}, key string, args ...interface{}) string {
+			return i18n.T(viewLocale(data), key, args...)
+		},
+		"LocalDate":     func(data interface{}, t time.Time) string { return i18n.FormatDate(viewLocale(data), t) },
+		"LocalDateTime": func(data interface{}, t time.Time) string { return i18n.FormatDateTime(viewLocale(data), t) },
+		"LocalLongDate": func(data interface{}, t time.Time) string { return i18n.FormatLongDate(viewLocale(data), t) },
+		"LocalNumber": func(data interface{}, value interface{}, decimals ...int) string {
+			d := 0
+			if len(decimals) > 0 {
+				d = decimals[0]
 			}
-			return t.Format("02.01.2006 15:04")
+			n, _ := strconv.ParseFloat(fmt.Sprint(value), 64)
+			return i18n.FormatNumber(viewLocale(data), n, d)
 		},
 
 		"hasPrefix": func(s, prefix string) bool {
 			return len(s) >= len(prefix) && s[:len(prefix)] == prefix
 		},
+
+		"can": func(user interface{}, permission string) bool {
+			checker, ok := user.(permissionChecker)
+			if !ok {
+				return false
+			}
+			return checker.HasPermission(permission)
+		},
+
+		// Form yardımcıları şablonun kök verisini ilk argüman olarak alır (ör. {{ fieldError . "Email" }});
+		// FuncMap tüm şablonlar için ortak olduğundan isteğe özel veri ancak bu şekilde okunabilir.
+		"fieldError": func(data interface{}, field string) string {
+			fieldErrors, _ := viewValue(data, "FieldErrors").(map[string]string)
+			return fieldErrors[field]
+		},
+
+		"old": func(data interface{}, field string, fallback ...interface{}) string {
+			if values, ok := oldInput(data)[field]; ok {
+				if len(values) == 0 {
+					return ""
+				}
+				return values[0]
+			}
+			if len(fallback) > 0 && fallback[0] != nil {
+				return fmt.Sprint(fallback[0])
+			}
+			return ""
+		},
+
+		// oldChecked, checkbox ve çoklu seçimler içindir: form geri gönderildiyse kullanıcının
+		// seçimi, aksi halde fallback kullanılır.
+		"oldChecked": func(data interface{}, field string, value interface{}, fallback bool) bool {
+			input := oldInput(data)
+			if input == nil {
+				return fallback
+			}
+			want := fmt.Sprint(value)
+			for _, v := range input[field] {
+				if v == want {
+					return true
+				}
+			}
+			return false
+		},
 	}
 	return fm
 }
+
+func viewValue(data interface{}, key string) interface{} {
+	switch m := data.(type) {
+	case fiber.Map:
+		return m[key]
+	case map[string]interface{}:
+		return m[key]
+	}
+	return nil
+}
+
+func viewLocale(data interface{}) string {
+	if locale, ok := viewValue(data, "Locale").(string); ok && locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+func oldInput(data interface{}) map[string][]string {
+	input, _ := viewValue(data, "OldInput").(map[string][]string)
+	return input
+}
@@ -0,0 +1,153 @@
+package trvalidation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// IsTCKN, T.C. Kimlik Numarasını uzunluk ve iki kontrol hanesiyle doğrular.
+func IsTCKN(value string) bool {
+	digits, ok := parseDigits(value, 11)
+	if !ok || digits[0] == 0 {
+		return false
+	}
+
+	odd := digits[0] + digits[2] + digits[4] + digits[6] + digits[8]
+	even := digits[1] + digits[3] + digits[5] + digits[7]
+	if ((odd*7-even)%10+10)%10 != digits[9] {
+		return false
+	}
+
+	sum := 0
+	for _, d := range digits[:10] {
+		sum += d
+	}
+	return sum%10 == digits[10]
+}
+
+// IsVKN, Gelir İdaresi'nin 10 haneli vergi kimlik numarası algoritmasını uygular.
+func IsVKN(value string) bool {
+	digits, ok := parseDigits(value, 10)
+	if !ok {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		tmp := (digits[i] + 9 - i) % 10
+		if tmp == 0 {
+			continue
+		}
+		v := (tmp << (9 - i)) % 9
+		if v == 0 {
+			v = 9
+		}
+		sum += v
+	}
+	return (10-sum%10)%10 == digits[9]
+}
+
+// NormalizeMobile, "0532 123 45 67", "532-123-4567" ya da "+90 532 123 45 67" gibi
+// yazımları E.164 biçimine (+905321234567) çevirir. Türkiye cep numarası değilse false döner.
+func NormalizeMobile(value string) (string, bool) {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && b.Len() == 0:
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false
+		}
+	}
+
+	number := b.String()
+	for _, prefix := range []string{"0090", "90", "0"} {
+		if len(number) > 10 && strings.HasPrefix(number, prefix) {
+			number = strings.TrimPrefix(number, prefix)
+			break
+		}
+	}
+	if len(number) != 10 || number[0] != '5' {
+		return "", false
+	}
+	return "+90" + number, true
+}
+
+// NormalizeIBAN, boşlukları kaldırır ve harfleri büyütür.
+func NormalizeIBAN(value string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(value), ""))
+}
+
+// IsTRIBAN, 26 karakterlik TR IBAN'ı biçim ve mod-97 kontrolüyle doğrular.
+func IsTRIBAN(value string) bool {
+	iban := NormalizeIBAN(value)
+	if len(iban) != 26 || !strings.HasPrefix(iban, "TR") {
+		return false
+	}
+	if _, ok := parseDigits(iban[2:], 24); !ok || iban[9] != '0' {
+		return false
+	}
+
+	// Ülke kodu ve kontrol haneleri sona taşınır, harfler sayıya çevrilir (A=10 ... Z=35).
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			n := int(r-'A') + 10
+			remainder = (remainder*100 + n) % 97
+			continue
+		}
+		remainder = (remainder*10 + int(r-'0')) % 97
+	}
+	return remainder == 1
+}
+
+// NormalizeName, baştaki/sondaki ve tekrarlanan boşlukları temizler.
+func NormalizeName(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// IsName, ad ve soyadlarını doğrular: harflerden oluşan ve tek boşluk, tire ya da kesme
+// işaretiyle ayrılan parçalar kabul edilir ("Ayşe Nur", "Çelik-Öztürk", "O'Neil").
+// Rakam ve diğer işaretler reddedilir.
+func IsName(value string) bool {
+	name := NormalizeName(value)
+	if len([]rune(name)) < 2 {
+		return false
+	}
+
+	prevSeparator := true
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r):
+			if !unicode.Is(unicode.Latin, r) {
+				return false
+			}
+			prevSeparator = false
+		case r == ' ' || r == '-' || r == '\'' || r == '’':
+			if prevSeparator {
+				return false
+			}
+			prevSeparator = true
+		default:
+			return false
+		}
+	}
+	return !prevSeparator
+}
+
+func parseDigits(value string, length int) ([]int, bool) {
+	if len(value) != length {
+		return nil, false
+	}
+	digits := make([]int, length)
+	for i := 0; i < length; i++ {
+		c := value[i]
+		if c < '0' || c > '9' {
+			return nil, false
+		}
+		digits[i] = int(c - '0')
+	}
+	return digits, true
+}
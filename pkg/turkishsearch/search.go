@@ -35,7 +35,7 @@ func MatchNormalized(text, keyword string) bool {
 func SQLFilter(columnName, search string) (string, []interface{}) {
 	filterValue := "%" + strings.ToLower(search) + "%"
 
-	query := "unaccent(lower(" + columnName + ")) ILIKE unaccent($1)"
+	query := "unaccent(lower(" + columnName + ")) ILIKE unaccent(?)"
 
 	params := []interface{}{filterValue}
 
@@ -0,0 +1,136 @@
+// Passkey kayıt ve giriş törenleri. Sunucu tarafı uç noktaları handlers/auth/webauthn_handler.go içindedir.
+(function () {
+  "use strict";
+
+  function base64urlToBuffer(value) {
+    const base64 = value.replace(/-/g, "+").replace(/_/g, "/");
+    const padded = base64 + "=".repeat((4 - (base64.length % 4)) % 4);
+    const binary = atob(padded);
+    const bytes = new Uint8Array(binary.length);
+    for (let i = 0; i < binary.length; i++) {
+      bytes[i] = binary.charCodeAt(i);
+    }
+    return bytes.buffer;
+  }
+
+  function bufferToBase64url(buffer) {
+    const bytes = new Uint8Array(buffer);
+    let binary = "";
+    for (let i = 0; i < bytes.length; i++) {
+      binary += String.fromCharCode(bytes[i]);
+    }
+    return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+  }
+
+  async function postJSON(url, csrfToken, body) {
+    const response = await fetch(url, {
+      method: "POST",
+      credentials: "same-origin",
+      headers: {
+        "Content-Type": "application/json",
+        "X-CSRF-Token": csrfToken,
+      },
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const data = await response.json().catch(function () {
+      return {};
+    });
+    if (!response.ok) {
+      throw new Error(data.detail || data.error || "İşlem tamamlanamadı.");
+    }
+    return data;
+  }
+
+  function showError(message) {
+    if (window.Swal) {
+      Swal.fire({ title: "Hata!", text: message, icon: "error" });
+    } else {
+      alert(message);
+    }
+  }
+
+  async function register(button) {
+    const csrfToken = button.dataset.csrf;
+    const nameInput = document.querySelector(button.dataset.nameInput || "");
+    const name = nameInput ? nameInput.value : "";
+
+    const options = await postJSON("/auth/profile/passkeys/register/begin", csrfToken);
+    const publicKey = options.publicKey;
+    publicKey.challenge = base64urlToBuffer(publicKey.challenge);
+    publicKey.user.id = base64urlToBuffer(publicKey.user.id);
+    (publicKey.excludeCredentials || []).forEach(function (credential) {
+      credential.id = base64urlToBuffer(credential.id);
+    });
+
+    const credential = await navigator.credentials.create({ publicKey: publicKey });
+    const result = await postJSON(
+      "/auth/profile/passkeys/register/finish?name=" + encodeURIComponent(name),
+      csrfToken,
+      {
+        id: credential.id,
+        rawId: bufferToBase64url(credential.rawId),
+        type: credential.type,
+        response: {
+          attestationObject: bufferToBase64url(credential.response.attestationObject),
+          clientDataJSON: bufferToBase64url(credential.response.clientDataJSON),
+          transports: credential.response.getTransports ? credential.response.getTransports() : [],
+        },
+      }
+    );
+    window.location.href = result.redirect;
+  }
+
+  async function login(button) {
+    const csrfToken = button.dataset.csrf;
+
+    const options = await postJSON("/auth/passkey/login/begin", csrfToken);
+    const publicKey = options.publicKey;
+    publicKey.challenge = base64urlToBuffer(publicKey.challenge);
+    (publicKey.allowCredentials || []).forEach(function (credential) {
+      credential.id = base64urlToBuffer(credential.id);
+    });
+
+    const assertion = await navigator.credentials.get({ publicKey: publicKey });
+    const result = await postJSON("/auth/passkey/login/finish", csrfToken, {
+      id: assertion.id,
+      rawId: bufferToBase64url(assertion.rawId),
+      type: assertion.type,
+      response: {
+        authenticatorData: bufferToBase64url(assertion.response.authenticatorData),
+        clientDataJSON: bufferToBase64url(assertion.response.clientDataJSON),
+        signature: bufferToBase64url(assertion.response.signature),
+        userHandle: assertion.response.userHandle ? bufferToBase64url(assertion.response.userHandle) : null,
+      },
+    });
+    window.location.href = result.redirect;
+  }
+
+  function bind(selector, ceremony) {
+    document.querySelectorAll(selector).forEach(function (button) {
+      if (!window.PublicKeyCredential) {
+        button.classList.add("d-none");
+        return;
+      }
+      button.addEventListener("click", function (event) {
+        event.preventDefault();
+        button.disabled = true;
+        ceremony(button)
+          .catch(function (err) {
+            // Kullanıcının tarayıcı penceresini kapatması hata olarak gösterilmez.
+            if (err && err.name === "NotAllowedError") {
+              return;
+            }
+            showError(err && err.message ? err.message : "Passkey işlemi tamamlanamadı.");
+          })
+          .finally(function () {
+            button.disabled = false;
+          });
+      });
+    });
+  }
+
+  document.addEventListener("DOMContentLoaded", function () {
+    bind("[data-passkey-register]", register);
+    bind("[data-passkey-login]", login);
+  });
+})();
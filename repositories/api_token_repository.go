@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IAPITokenRepository interface {
+	GetByUser(userID uint) ([]models.APIToken, error)
+	FindByHash(tokenHash string) (*models.APIToken, error)
+	Create(ctx context.Context, token *models.APIToken) error
+	Touch(ctx context.Context, id uint, ip string) error
+	DeleteForUser(ctx context.Context, userID, id uint) (int64, error)
+	DeleteAllForUser(ctx context.Context, userID uint) (int64, error)
+}
+
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository() IAPITokenRepository {
+	return &APITokenRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *APITokenRepository) GetByUser(userID uint) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at desc").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *APITokenRepository) FindByHash(tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *APITokenRepository) Create(ctx context.Context, token *models.APIToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *APITokenRepository) Touch(ctx context.Context, id uint, ip string) error {
+	return r.db.WithContext(ctx).Model(&models.APIToken{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": time.Now().UTC(),
+			"last_used_ip": ip,
+		}).Error
+}
+
+func (r *APITokenRepository) DeleteForUser(ctx context.Context, userID, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.APIToken{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *APITokenRepository) DeleteAllForUser(ctx context.Context, userID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.APIToken{})
+	return result.RowsAffected, result.Error
+}
+
+var _ IAPITokenRepository = (*APITokenRepository)(nil)
@@ -16,9 +16,7 @@ type IAuthRepository interface {
 	FindUserByID(id uint) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
 	CreateUser(ctx context.Context, user *models.User) error
-	FindUserByResetToken(token string) (*models.User, error)
-	FindUserByVerificationToken(token string) (*models.User, error)
-	FindByProviderAndID(provider, providerID string) (*models.User, error)
+	UpdateUserColumns(ctx context.Context, userID uint, data map[string]interface{}) error
 }
 
 type AuthRepository struct {
@@ -57,7 +55,7 @@ func (r *AuthRepository) FindUserByEmail(email string) (*models.User, error) {
 
 func (r *AuthRepository) FindUserByID(id uint) (*models.User, error) {
 	return r.findUser(
-		r.db.Where("id = ?", id),
+		r.db.Preload("Roles.Permissions").Where("id = ?", id),
 		"Kullanıcı sorgulama (ID)",
 		zap.Uint("user_id", id),
 	)
@@ -80,28 +78,11 @@ func (r *AuthRepository) CreateUser(ctx context.Context, user *models.User) erro
 	)
 }
 
-func (r *AuthRepository) FindUserByResetToken(token string) (*models.User, error) {
-	return r.findUser(
-		r.db.Where("reset_token = ?", token),
-		"Kullanıcı sorgulama (reset token)",
-		zap.String("reset_token", token),
-	)
-}
-
-func (r *AuthRepository) FindUserByVerificationToken(token string) (*models.User, error) {
-	return r.findUser(
-		r.db.Where("verification_token = ?", token),
-		"Kullanıcı sorgulama (verification token)",
-		zap.String("verification_token", token),
-	)
-}
-
-func (r *AuthRepository) FindByProviderAndID(provider, providerID string) (*models.User, error) {
-	return r.findUser(
-		r.db.Where("provider = ? AND provider_id = ?", provider, providerID),
-		"Kullanıcı sorgulama (provider ve ID)",
-		zap.String("provider", provider),
-		zap.String("provider_id", providerID),
+func (r *AuthRepository) UpdateUserColumns(ctx context.Context, userID uint, data map[string]interface{}) error {
+	return r.executeQuery(
+		r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Updates(data),
+		"Kullanıcı alanları güncelleme",
+		zap.Uint("user_id", userID),
 	)
 }
 
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IAuthTokenRepository interface {
+	Create(ctx context.Context, token *models.AuthToken) error
+	Consume(ctx context.Context, tokenHash string, purpose models.AuthTokenPurpose) (*models.AuthToken, error)
+	DeleteForUser(ctx context.Context, userID uint, purpose models.AuthTokenPurpose) error
+}
+
+type AuthTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthTokenRepository() IAuthTokenRepository {
+	return &AuthTokenRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *AuthTokenRepository) Create(ctx context.Context, token *models.AuthToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// Consume, geçerli ve kullanılmamış tokenı tek bir UPDATE ... RETURNING ile
+// kullanılmış olarak işaretler; eşzamanlı iki istekten yalnızca biri başarılı olur.
+func (r *AuthTokenRepository) Consume(ctx context.Context, tokenHash string, purpose models.AuthTokenPurpose) (*models.AuthToken, error) {
+	var token models.AuthToken
+	now := time.Now().UTC()
+	result := r.db.WithContext(ctx).
+		Model(&token).
+		Clauses(clause.Returning{}).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &token, nil
+}
+
+func (r *AuthTokenRepository) DeleteForUser(ctx context.Context, userID uint, purpose models.AuthTokenPurpose) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND purpose = ?", userID, purpose).
+		Delete(&models.AuthToken{}).Error
+}
+
+var _ IAuthTokenRepository = (*AuthTokenRepository)(nil)
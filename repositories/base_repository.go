@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"zatrano/pkg/queryparams"
-	"zatrano/pkg/turkishsearch"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -21,6 +21,7 @@ var (
 
 type IBaseRepository[T any] interface {
 	GetAll(params queryparams.ListParams) ([]T, int64, error)
+	GetAllByCursor(params queryparams.ListParams) ([]T, queryparams.CursorMeta, error)
 	GetByID(id uint) (*T, error)
 	Create(ctx context.Context, entity *T) error
 	CreateWithRelations(ctx context.Context, entity *T) error
@@ -36,11 +37,18 @@ type IBaseRepository[T any] interface {
 	BulkDeleteWithRelations(ctx context.Context, ids []uint) error
 	GetCount() (int64, error)
 	CountByCondition(condition map[string]interface{}) (int64, error)
+	GetTrashed(params queryparams.ListParams) ([]T, int64, error)
+	GetTrashedByID(id uint) (*T, error)
+	Restore(ctx context.Context, id uint) error
+	ForceDelete(ctx context.Context, id uint) error
+	PurgeOlderThan(age time.Duration) (int64, error)
 }
 
 type BaseRepository[T any] struct {
 	db                 *gorm.DB
 	allowedSortColumns map[string]bool
+	cursorSortColumns  map[string]bool
+	filterableFields   map[string]filterableField
 	preloads           []string
 }
 
@@ -51,6 +59,10 @@ func NewBaseRepository[T any](db *gorm.DB) *BaseRepository[T] {
 			"id":         true,
 			"created_at": true,
 		},
+		cursorSortColumns: map[string]bool{
+			"id":         true,
+			"created_at": true,
+		},
 	}
 }
 
@@ -61,32 +73,46 @@ func (r *BaseRepository[T]) SetAllowedSortColumns(columns []string) {
 	}
 }
 
+// SetCursorSortColumns, GetAllByCursor'un sıralamada kabul edeceği kolonları tanımlar.
+// Keyset karşılaştırması NULL değerlerde çalışmadığı için yalnızca NOT NULL kolonlar
+// verilmelidir; bu liste SetAllowedSortColumns'dan bağımsızdır.
+func (r *BaseRepository[T]) SetCursorSortColumns(columns []string) {
+	r.cursorSortColumns = make(map[string]bool)
+	for _, col := range columns {
+		r.cursorSortColumns[col] = true
+	}
+}
+
 func (r *BaseRepository[T]) SetPreloads(preloads ...string) {
 	r.preloads = preloads
 }
 
 func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, error) {
+	var t T
+	return r.list(r.db.Model(&t), params)
+}
+
+// GetTrashed, soft-delete ile silinmiş kayıtları GetAll ile aynı filtre, sıralama ve
+// sayfalama kurallarıyla listeler.
+func (r *BaseRepository[T]) GetTrashed(params queryparams.ListParams) ([]T, int64, error) {
+	var t T
+	return r.list(r.db.Unscoped().Model(&t).Where("deleted_at IS NOT NULL"), params)
+}
+
+func (r *BaseRepository[T]) list(query *gorm.DB, params queryparams.ListParams) ([]T, int64, error) {
 	var results []T
 	var totalCount int64
-	var t T
 
-	query := r.db.Model(&t)
 	for _, preload := range r.preloads {
 		query = query.Preload(preload)
 	}
 
-	if params.Name != "" {
-		sqlFragment, args := turkishsearch.SQLFilter("name", params.Name)
-		query = query.Where(sqlFragment, args...)
-	}
-	if params.Status != "" {
-		query = query.Where("status = ?", params.Status)
-	}
-	if params.Type != "" {
-		query = query.Where("type = ?", params.Type)
+	query, err := r.applyFilters(query, params.EffectiveFilters())
+	if err != nil {
+		return nil, 0, err
 	}
 
-	err := query.Count(&totalCount).Error
+	err = query.Count(&totalCount).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -94,6 +120,18 @@ func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, e
 		return results, 0, nil
 	}
 
+	sortBy, orderBy := r.sortOrder(params)
+	query = query.Order(sortBy + " " + orderBy)
+
+	offset := params.CalculateOffset()
+	query = query.Limit(params.PerPage).Offset(offset)
+
+	err = query.Find(&results).Error
+	return results, totalCount, err
+}
+
+// sortOrder, istenen sıralamayı izin verilen kolonlar ve yönlerle sınırlar.
+func (r *BaseRepository[T]) sortOrder(params queryparams.ListParams) (string, string) {
 	sortBy := params.SortBy
 	orderBy := strings.ToLower(params.OrderBy)
 	if orderBy != "asc" && orderBy != "desc" {
@@ -102,13 +140,7 @@ func (r *BaseRepository[T]) GetAll(params queryparams.ListParams) ([]T, int64, e
 	if _, ok := r.allowedSortColumns[sortBy]; !ok {
 		sortBy = queryparams.DefaultSortBy
 	}
-	query = query.Order(sortBy + " " + orderBy)
-
-	offset := params.CalculateOffset()
-	query = query.Limit(params.PerPage).Offset(offset)
-
-	err = query.Find(&results).Error
-	return results, totalCount, err
+	return sortBy, orderBy
 }
 
 func (r *BaseRepository[T]) GetByID(id uint) (*T, error) {
@@ -269,6 +301,56 @@ func (r *BaseRepository[T]) BulkDeleteWithRelations(ctx context.Context, ids []u
 	return tx.Select(clause.Associations).Delete(&entities).Error
 }
 
+// GetTrashedByID, yalnızca silinmiş kayıtlar arasında arama yapar.
+func (r *BaseRepository[T]) GetTrashedByID(id uint) (*T, error) {
+	var result T
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&result, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	return &result, err
+}
+
+// Restore, silinmiş bir kaydı geri getirir ve deleted_by alanını temizler.
+func (r *BaseRepository[T]) Restore(ctx context.Context, id uint) error {
+	var t T
+	result := r.db.WithContext(ctx).Unscoped().Model(&t).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "deleted_by": nil})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ForceDelete, çöp kutusundaki bir kaydı veritabanından kalıcı olarak siler; silinmemiş
+// kayıtlar için ErrNotFound döner. Bağlı tablolardaki satırlar foreign key'lerin
+// ON DELETE CASCADE kuralıyla silinir.
+func (r *BaseRepository[T]) ForceDelete(ctx context.Context, id uint) error {
+	var t T
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Delete(&t, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// PurgeOlderThan, age süresinden daha önce silinmiş kayıtları kalıcı olarak siler ve
+// silinen kayıt sayısını döner.
+func (r *BaseRepository[T]) PurgeOlderThan(age time.Duration) (int64, error) {
+	var t T
+	result := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", time.Now().Add(-age)).
+		Delete(&t)
+	return result.RowsAffected, result.Error
+}
+
 func (r *BaseRepository[T]) GetCount() (int64, error) {
 	var totalCount int64
 	var t T
@@ -0,0 +1,232 @@
+package repositories
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"zatrano/pkg/queryparams"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// GetAllByCursor, GetAll'un keyset (cursor) sayfalamalı karşılığıdır. OFFSET yerine
+// son görülen satırın sıralama değeri ve id'sinden devam eder; böylece büyük
+// tablolarda sayfa derinliği sorguyu yavaşlatmaz ve sayfalar arasında eklenen ya da
+// silinen satırlar kayma yaratmaz. Toplam sayı yalnızca params.Count ile istenirse
+// hesaplanır. Yalnızca SetCursorSortColumns ile izin verilen kolonlara göre sıralanabilir;
+// diğer sortBy değerleri ErrInvalidCursor döner.
+func (r *BaseRepository[T]) GetAllByCursor(params queryparams.ListParams) ([]T, queryparams.CursorMeta, error) {
+	var results []T
+	var t T
+	meta := queryparams.CursorMeta{PerPage: params.PerPage}
+
+	// Offset sayfalamadaki gibi varsayılana düşmek yerine reddedilir; NULL içerebilen
+	// bir kolona göre keyset sıralaması sessizce yanlış sonuç verirdi.
+	sortBy := params.SortBy
+	if sortBy == "" {
+		sortBy = queryparams.DefaultSortBy
+	}
+	if !r.cursorSortColumns[sortBy] {
+		return nil, meta, fmt.Errorf("%w: cursor sayfalamada %s kolonuna göre sıralanamaz", queryparams.ErrInvalidCursor, sortBy)
+	}
+	_, orderBy := r.sortOrder(params)
+
+	query := r.db.Model(&t)
+	for _, preload := range r.preloads {
+		query = query.Preload(preload)
+	}
+
+	filters := params.EffectiveFilters()
+	query, err := r.applyFilters(query, filters)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	switch params.Count {
+	case queryparams.CountExact:
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return nil, meta, err
+		}
+		meta.TotalItems = &total
+	case queryparams.CountEstimated:
+		total, estimated, err := r.estimateCount(query, len(filters) > 0)
+		if err != nil {
+			return nil, meta, err
+		}
+		meta.TotalItems, meta.TotalEstimated = &total, estimated
+	}
+
+	backward := false
+	if params.Cursor != "" {
+		cursor, err := queryparams.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, meta, err
+		}
+		if cursor.SortBy != sortBy || cursor.OrderBy != orderBy {
+			return nil, meta, fmt.Errorf("%w: cursor farklı bir sıralamaya ait", queryparams.ErrInvalidCursor)
+		}
+		backward = cursor.Prev
+
+		comparison := "<"
+		if (orderBy == "asc") != backward {
+			comparison = ">"
+		}
+		if sortBy == "id" {
+			query = query.Where("id "+comparison+" ?", cursor.ID)
+		} else {
+			query = query.Where("("+sortBy+", id) "+comparison+" (?, ?)", cursor.Value, cursor.ID)
+		}
+	}
+
+	direction := orderBy
+	if backward {
+		direction = reverseOrder(orderBy)
+	}
+	if sortBy == "id" {
+		query = query.Order("id " + direction)
+	} else {
+		query = query.Order(sortBy + " " + direction).Order("id " + direction)
+	}
+
+	if err := query.Limit(params.PerPage + 1).Find(&results).Error; err != nil {
+		return nil, meta, err
+	}
+
+	hasMore := len(results) > params.PerPage
+	if hasMore {
+		results = results[:params.PerPage]
+	}
+	if backward {
+		for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+			results[i], results[j] = results[j], results[i]
+		}
+	}
+	if len(results) == 0 {
+		return results, meta, nil
+	}
+
+	modelSchema, err := r.schema()
+	if err != nil {
+		return nil, meta, err
+	}
+	first, last := &results[0], &results[len(results)-1]
+	if backward {
+		meta.Next, err = encodeCursor(modelSchema, last, sortBy, orderBy, false)
+		if err == nil && hasMore {
+			meta.Prev, err = encodeCursor(modelSchema, first, sortBy, orderBy, true)
+		}
+	} else {
+		if hasMore {
+			meta.Next, err = encodeCursor(modelSchema, last, sortBy, orderBy, false)
+		}
+		if err == nil && params.Cursor != "" {
+			meta.Prev, err = encodeCursor(modelSchema, first, sortBy, orderBy, true)
+		}
+	}
+	return results, meta, err
+}
+
+func (r *BaseRepository[T]) schema() (*schema.Schema, error) {
+	var t T
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(&t); err != nil {
+		return nil, err
+	}
+	return stmt.Schema, nil
+}
+
+func encodeCursor[T any](modelSchema *schema.Schema, row *T, sortBy, orderBy string, prev bool) (string, error) {
+	if modelSchema.PrioritizedPrimaryField == nil {
+		return "", fmt.Errorf("%s tablosunda birincil anahtar yok", modelSchema.Table)
+	}
+
+	rowValue := reflect.ValueOf(row).Elem()
+	id, _ := modelSchema.PrioritizedPrimaryField.ValueOf(context.Background(), rowValue)
+	cursor := queryparams.Cursor{SortBy: sortBy, OrderBy: orderBy, Prev: prev}
+	if idValue, ok := id.(uint); ok {
+		cursor.ID = idValue
+	}
+
+	if sortBy != "id" {
+		field := modelSchema.LookUpField(sortBy)
+		if field == nil {
+			return "", fmt.Errorf("%s tablosunda %s kolonu yok", modelSchema.Table, sortBy)
+		}
+		value, _ := field.ValueOf(context.Background(), rowValue)
+		encoded, err := cursorValue(value)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", modelSchema.Table, sortBy, err)
+		}
+		cursor.Value = encoded
+	}
+	return queryparams.EncodeCursor(cursor), nil
+}
+
+// cursorValue, sıralama kolonunun değerini cursor'a yazılacak metne çevirir.
+// gorm.DeletedAt ve sql.Null* gibi driver.Valuer tipleri önce veritabanı değerine
+// indirgenir; NULL değer keyset karşılaştırmasında kullanılamayacağı için hata döner.
+func cursorValue(value interface{}) (string, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		value = v
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			value = nil
+		} else {
+			value = rv.Elem().Interface()
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return "", errors.New("NULL değer cursor'a yazılamaz")
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []byte:
+		return string(v), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// estimateCount, filtre yoksa satır sayısını pg_class istatistiklerinden okur; bu
+// değer ANALYZE ile güncellenir ve soft-delete ile silinmiş satırları da kapsar.
+// Filtre varsa ya da tablo henüz analiz edilmemişse tam sayım yapılır.
+func (r *BaseRepository[T]) estimateCount(query *gorm.DB, filtered bool) (int64, bool, error) {
+	if !filtered {
+		modelSchema, err := r.schema()
+		if err != nil {
+			return 0, false, err
+		}
+
+		var estimate float64
+		err = r.db.Raw("SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)", modelSchema.Table).Scan(&estimate).Error
+		if err != nil {
+			return 0, false, err
+		}
+		if estimate > 0 {
+			return int64(estimate), true, nil
+		}
+	}
+
+	var total int64
+	err := query.Count(&total).Error
+	return total, false, err
+}
+
+func reverseOrder(orderBy string) string {
+	if orderBy == "asc" {
+		return "desc"
+	}
+	return "asc"
+}
@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/pkg/queryparams"
+	"zatrano/pkg/turkishsearch"
+
+	"gorm.io/gorm"
+)
+
+const filterDateLayout = "2006-01-02"
+
+var ErrInvalidFilter = errors.New("geçersiz filtre")
+
+// FilterKind, filtre değerinin SQL'e verilmeden önce hangi tipe çevrileceğini belirtir.
+type FilterKind int
+
+const (
+	FilterString FilterKind = iota
+	FilterInt
+	FilterBool
+	// FilterEnum, değerin FilterField.Values içinde olmasını ister.
+	FilterEnum
+	// FilterDate, yalnızca date-between ile kullanılan tarih kolonlarıdır.
+	FilterDate
+)
+
+// FilterField, filtrelenebilir bir kolonun değer tipini ve izin verilen operatörlerini tanımlar.
+type FilterField struct {
+	Kind   FilterKind
+	Ops    []queryparams.FilterOp
+	Values []string
+}
+
+type filterableField struct {
+	kind   FilterKind
+	ops    map[queryparams.FilterOp]bool
+	values map[string]bool
+}
+
+// SetFilterableFields, GetAll ve GetTrashed'in kabul edeceği filtreleri kolon adı,
+// değer tipi ve izin verilen operatörlerle tanımlar. Listede olmayan alan veya
+// operatörle ya da kolon tipine uymayan değerle gelen filtreler ErrInvalidFilter döner.
+func (r *BaseRepository[T]) SetFilterableFields(fields map[string]FilterField) {
+	r.filterableFields = make(map[string]filterableField, len(fields))
+	for column, field := range fields {
+		allowed := filterableField{
+			kind:   field.Kind,
+			ops:    make(map[queryparams.FilterOp]bool, len(field.Ops)),
+			values: make(map[string]bool, len(field.Values)),
+		}
+		for _, op := range field.Ops {
+			allowed.ops[op] = true
+		}
+		for _, value := range field.Values {
+			allowed.values[value] = true
+		}
+		r.filterableFields[column] = allowed
+	}
+}
+
+func (r *BaseRepository[T]) applyFilters(query *gorm.DB, filters queryparams.Filters) (*gorm.DB, error) {
+	// Alanlar ve operatörler sıralı gezilir; aynı istek her zaman aynı SQL'i üretir.
+	columns := make([]string, 0, len(filters))
+	for column := range filters {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		ops := make([]string, 0, len(filters[column]))
+		for op := range filters[column] {
+			ops = append(ops, string(op))
+		}
+		sort.Strings(ops)
+
+		for _, name := range ops {
+			op, value := queryparams.FilterOp(name), filters[column][queryparams.FilterOp(name)]
+			field, ok := r.filterableFields[column]
+			if !ok || !field.ops[op] {
+				return nil, fmt.Errorf("%w: filter[%s][%s] desteklenmiyor", ErrInvalidFilter, column, op)
+			}
+			var err error
+			if query, err = applyFilter(query, column, field, op, value); err != nil {
+				return nil, fmt.Errorf("%w: filter[%s][%s]: %v", ErrInvalidFilter, column, op, err)
+			}
+		}
+	}
+	return query, nil
+}
+
+// applyFilter, column yalnızca izin listesinden geldiği için SQL'e doğrudan yazılır;
+// değerler kolon tipine çevrilip her zaman parametre olarak geçer.
+func applyFilter(query *gorm.DB, column string, field filterableField, op queryparams.FilterOp, value string) (*gorm.DB, error) {
+	switch op {
+	case queryparams.FilterEq:
+		typed, err := field.convert(value)
+		if err != nil {
+			return nil, err
+		}
+		return query.Where(column+" = ?", typed), nil
+
+	case queryparams.FilterIn:
+		values := splitFilterList(value)
+		if len(values) == 0 {
+			return nil, errors.New("en az bir değer gerekli")
+		}
+		typed := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			converted, err := field.convert(v)
+			if err != nil {
+				return nil, err
+			}
+			typed = append(typed, converted)
+		}
+		return query.Where(column+" IN ?", typed), nil
+
+	case queryparams.FilterRange:
+		from, to, err := splitFilterBounds(value)
+		if err != nil {
+			return nil, err
+		}
+		if from != "" {
+			typed, err := field.convert(from)
+			if err != nil {
+				return nil, err
+			}
+			query = query.Where(column+" >= ?", typed)
+		}
+		if to != "" {
+			typed, err := field.convert(to)
+			if err != nil {
+				return nil, err
+			}
+			query = query.Where(column+" <= ?", typed)
+		}
+		return query, nil
+
+	case queryparams.FilterLike:
+		sqlFragment, args := turkishsearch.SQLFilter(column, value)
+		return query.Where(sqlFragment, args...), nil
+
+	case queryparams.FilterDateBetween:
+		from, to, err := splitFilterBounds(value)
+		if err != nil {
+			return nil, err
+		}
+		if from != "" {
+			start, err := time.Parse(filterDateLayout, from)
+			if err != nil {
+				return nil, fmt.Errorf("tarih YYYY-AA-GG biçiminde olmalı: %s", from)
+			}
+			query = query.Where(column+" >= ?", start)
+		}
+		if to != "" {
+			end, err := time.Parse(filterDateLayout, to)
+			if err != nil {
+				return nil, fmt.Errorf("tarih YYYY-AA-GG biçiminde olmalı: %s", to)
+			}
+			query = query.Where(column+" < ?", end.AddDate(0, 0, 1))
+		}
+		return query, nil
+
+	case queryparams.FilterIsNull:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("değer true ya da false olmalı")
+		}
+		if isNull {
+			return query.Where(column + " IS NULL"), nil
+		}
+		return query.Where(column + " IS NOT NULL"), nil
+	}
+	return nil, errors.New("bilinmeyen operatör")
+}
+
+// convert, eq, in ve range değerlerini kolon tipine çevirir; böylece hatalı değerler
+// veritabanına ulaşmadan 400 ile reddedilir.
+func (f filterableField) convert(value string) (interface{}, error) {
+	switch f.kind {
+	case FilterInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("değer tam sayı olmalı: %s", value)
+		}
+		return n, nil
+	case FilterBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("değer true ya da false olmalı: %s", value)
+		}
+		return b, nil
+	case FilterEnum:
+		if !f.values[value] {
+			return nil, fmt.Errorf("izin verilmeyen değer: %s", value)
+		}
+		return value, nil
+	case FilterDate:
+		return nil, errors.New("tarih kolonları yalnızca date-between ile filtrelenebilir")
+	}
+	return value, nil
+}
+
+func splitFilterList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// splitFilterBounds, "alt,üst" biçimindeki değeri ayırır; sınırlardan biri boş olabilir.
+func splitFilterBounds(value string) (string, string, error) {
+	from, to, ok := strings.Cut(value, ",")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if !ok || (from == "" && to == "") {
+		return "", "", errors.New("değer alt,üst biçiminde olmalı")
+	}
+	return from, to, nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+	"zatrano/pkg/queryparams"
+
+	"gorm.io/gorm"
+)
+
+type IRoleRepository interface {
+	GetAllRoles(params queryparams.ListParams) ([]models.Role, int64, error)
+	GetRoleList() ([]models.Role, error)
+	GetRoleByID(id uint) (*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role) error
+	UpdateRole(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
+	DeleteRole(ctx context.Context, id uint) error
+	ReplaceRolePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error
+	GetAllPermissions() ([]models.Permission, error)
+}
+
+type RoleRepository struct {
+	base IBaseRepository[models.Role]
+	db   *gorm.DB
+}
+
+func NewRoleRepository() IRoleRepository {
+	base := NewBaseRepository[models.Role](databaseconfig.GetDB())
+	base.SetAllowedSortColumns([]string{"id", "name", "created_at"})
+	base.SetCursorSortColumns([]string{"id", "name", "created_at"})
+	base.SetFilterableFields(map[string]FilterField{
+		"id":         {Kind: FilterInt, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterIn}},
+		"name":       {Kind: FilterString, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterLike}},
+		"created_at": {Kind: FilterDate, Ops: []queryparams.FilterOp{queryparams.FilterDateBetween}},
+	})
+	base.SetPreloads("Permissions")
+
+	return &RoleRepository{base: base, db: databaseconfig.GetDB()}
+}
+
+func (r *RoleRepository) GetAllRoles(params queryparams.ListParams) ([]models.Role, int64, error) {
+	return r.base.GetAll(params)
+}
+
+func (r *RoleRepository) GetRoleList() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Order("name asc").Find(&roles).Error
+	return roles, err
+}
+
+func (r *RoleRepository) GetRoleByID(id uint) (*models.Role, error) {
+	return r.base.GetByID(id)
+}
+
+func (r *RoleRepository) CreateRole(ctx context.Context, role *models.Role) error {
+	return r.base.Create(ctx, role)
+}
+
+func (r *RoleRepository) UpdateRole(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error {
+	return r.base.Update(ctx, id, data, updatedBy)
+}
+
+func (r *RoleRepository) DeleteRole(ctx context.Context, id uint) error {
+	return r.base.Delete(ctx, id)
+}
+
+func (r *RoleRepository) ReplaceRolePermissions(ctx context.Context, roleID uint, permissionIDs []uint) error {
+	permissions := []models.Permission{}
+	if len(permissionIDs) > 0 {
+		if err := r.db.WithContext(ctx).Find(&permissions, permissionIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	role := models.Role{BaseModel: models.BaseModel{ID: roleID}}
+	return r.db.WithContext(ctx).Model(&role).Omit("Permissions.*").Association("Permissions").Replace(permissions)
+}
+
+func (r *RoleRepository) GetAllPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := r.db.Order("name asc").Find(&permissions).Error
+	return permissions, err
+}
+
+var _ IRoleRepository = (*RoleRepository)(nil)
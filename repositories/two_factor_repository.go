@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type ITwoFactorRepository interface {
+	Enable(ctx context.Context, userID uint, secret string, counter int64, codeHashes []string) error
+	Disable(ctx context.Context, userID uint) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	ConsumeRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error)
+	ConsumeTOTPCounter(ctx context.Context, userID uint, counter int64) (bool, error)
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+}
+
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository() ITwoFactorRepository {
+	return &TwoFactorRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *TwoFactorRepository) Enable(ctx context.Context, userID uint, secret string, counter int64, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_secret":       secret,
+			"two_factor_enabled":      true,
+			"two_factor_last_counter": counter,
+		}).Error; err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func (r *TwoFactorRepository) Disable(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"two_factor_secret":       "",
+			"two_factor_enabled":      false,
+			"two_factor_last_counter": 0,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error
+	})
+}
+
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func (r *TwoFactorRepository) ConsumeRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ConsumeTOTPCounter, TOTP adımını yalnızca son kabul edilenden büyükse kaydeder.
+// Koşul UPDATE içinde kontrol edildiği için eşzamanlı iki istekten yalnızca biri kabul edilir.
+func (r *TwoFactorRepository) ConsumeTOTPCounter(ctx context.Context, userID uint, counter int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ? AND two_factor_last_counter < ?", userID, counter).
+		Update("two_factor_last_counter", counter)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+	codes := make([]models.UserRecoveryCode, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		codes = append(codes, models.UserRecoveryCode{UserID: userID, CodeHash: codeHash})
+	}
+	return tx.Create(&codes).Error
+}
+
+var _ ITwoFactorRepository = (*TwoFactorRepository)(nil)
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IUserIdentityRepository interface {
+	FindByProviderSubject(provider, subject string) (*models.UserIdentity, error)
+	FindUserByEmailFold(email string) (*models.User, error)
+	GetByUser(userID uint) ([]models.UserIdentity, error)
+	Create(ctx context.Context, identity *models.UserIdentity) error
+	CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error
+	Touch(ctx context.Context, id uint) error
+	DeleteForUser(ctx context.Context, userID, id uint) (int64, error)
+}
+
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository() IUserIdentityRepository {
+	return &UserIdentityRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *UserIdentityRepository) FindByProviderSubject(provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	if err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindUserByEmailFold, sağlayıcıların döndürdüğü adreslerin büyük/küçük harf farkını yok sayar.
+func (r *UserIdentityRepository) FindUserByEmailFold(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserIdentityRepository) GetByUser(userID uint) ([]models.UserIdentity, error) {
+	var identities []models.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&identities).Error
+	return identities, err
+}
+
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *models.UserIdentity) error {
+	return r.db.WithContext(ctx).Create(identity).Error
+}
+
+func (r *UserIdentityRepository) CreateUserWithIdentity(ctx context.Context, user *models.User, identity *models.UserIdentity) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
+func (r *UserIdentityRepository) Touch(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.UserIdentity{}).
+		Where("id = ?", id).
+		Update("last_used_at", time.Now().UTC()).Error
+}
+
+func (r *UserIdentityRepository) DeleteForUser(ctx context.Context, userID, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.UserIdentity{})
+	return result.RowsAffected, result.Error
+}
+
+var _ IUserIdentityRepository = (*UserIdentityRepository)(nil)
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"zatrano/configs/databaseconfig"
 	"zatrano/models"
@@ -12,7 +14,9 @@ import (
 
 type IUserRepository interface {
 	GetAllUsers(params queryparams.ListParams) ([]models.User, int64, error)
+	GetUsersByCursor(params queryparams.ListParams) ([]models.User, queryparams.CursorMeta, error)
 	GetUserByID(id uint) (*models.User, error)
+	GetUserWithPermissions(id uint) (*models.User, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	BulkCreateUsers(ctx context.Context, users []models.User) error
 	UpdateUser(ctx context.Context, id uint, data map[string]interface{}, updatedBy uint) error
@@ -20,6 +24,12 @@ type IUserRepository interface {
 	DeleteUser(ctx context.Context, id uint) error
 	BulkDeleteUsers(ctx context.Context, condition map[string]interface{}) error
 	GetUserCount() (int64, error)
+	GetTrashedUsers(params queryparams.ListParams) ([]models.User, int64, error)
+	GetTrashedUserByID(id uint) (*models.User, error)
+	RestoreUser(ctx context.Context, id uint) error
+	ForceDeleteUser(ctx context.Context, id uint) error
+	PurgeTrashedUsers(age time.Duration) (int64, error)
+	ReplaceUserRoles(ctx context.Context, userID uint, roleIDs []uint) error
 }
 
 type UserRepository struct {
@@ -29,7 +39,24 @@ type UserRepository struct {
 
 func NewUserRepository() IUserRepository {
 	base := NewBaseRepository[models.User](databaseconfig.GetDB())
-	base.SetAllowedSortColumns([]string{"id", "name", "email", "created_at", "status", "type"})
+	base.SetAllowedSortColumns([]string{"id", "name", "email", "created_at", "status", "type", "deleted_at"})
+	base.SetCursorSortColumns([]string{"id", "name", "email", "created_at", "type"})
+	base.SetFilterableFields(map[string]FilterField{
+		"id":                  {Kind: FilterInt, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterIn, queryparams.FilterRange}},
+		"name":                {Kind: FilterString, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterLike}},
+		"email":               {Kind: FilterString, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterLike}},
+		"type":                {Kind: FilterEnum, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterIn}, Values: models.UserType("").EnumValues()},
+		"status":              {Kind: FilterBool, Ops: []queryparams.FilterOp{queryparams.FilterEq}},
+		"email_verified":      {Kind: FilterBool, Ops: []queryparams.FilterOp{queryparams.FilterEq}},
+		"two_factor_enabled":  {Kind: FilterBool, Ops: []queryparams.FilterOp{queryparams.FilterEq}},
+		"two_factor_required": {Kind: FilterBool, Ops: []queryparams.FilterOp{queryparams.FilterEq}},
+		"locale":              {Kind: FilterString, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterIn}},
+		"created_at":          {Kind: FilterDate, Ops: []queryparams.FilterOp{queryparams.FilterDateBetween}},
+		"updated_at":          {Kind: FilterDate, Ops: []queryparams.FilterOp{queryparams.FilterDateBetween}},
+		"deleted_at":          {Kind: FilterDate, Ops: []queryparams.FilterOp{queryparams.FilterDateBetween}},
+		"deleted_by":          {Kind: FilterInt, Ops: []queryparams.FilterOp{queryparams.FilterEq, queryparams.FilterIsNull}},
+	})
+	base.SetPreloads("Roles")
 
 	return &UserRepository{base: base, db: databaseconfig.GetDB()}
 }
@@ -38,10 +65,24 @@ func (r *UserRepository) GetAllUsers(params queryparams.ListParams) ([]models.Us
 	return r.base.GetAll(params)
 }
 
+func (r *UserRepository) GetUsersByCursor(params queryparams.ListParams) ([]models.User, queryparams.CursorMeta, error) {
+	return r.base.GetAllByCursor(params)
+}
+
 func (r *UserRepository) GetUserByID(id uint) (*models.User, error) {
 	return r.base.GetByID(id)
 }
 
+// GetUserWithPermissions, kullanıcıyı rolleri ve rollerin yetkileriyle birlikte döner.
+func (r *UserRepository) GetUserWithPermissions(id uint) (*models.User, error) {
+	var user models.User
+	err := r.db.Preload("Roles.Permissions").First(&user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotFound
+	}
+	return &user, err
+}
+
 func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
 	return r.base.Create(ctx, user)
 }
@@ -70,5 +111,37 @@ func (r *UserRepository) GetUserCount() (int64, error) {
 	return r.base.GetCount()
 }
 
+func (r *UserRepository) GetTrashedUsers(params queryparams.ListParams) ([]models.User, int64, error) {
+	return r.base.GetTrashed(params)
+}
+
+func (r *UserRepository) GetTrashedUserByID(id uint) (*models.User, error) {
+	return r.base.GetTrashedByID(id)
+}
+
+func (r *UserRepository) RestoreUser(ctx context.Context, id uint) error {
+	return r.base.Restore(ctx, id)
+}
+
+func (r *UserRepository) ForceDeleteUser(ctx context.Context, id uint) error {
+	return r.base.ForceDelete(ctx, id)
+}
+
+func (r *UserRepository) PurgeTrashedUsers(age time.Duration) (int64, error) {
+	return r.base.PurgeOlderThan(age)
+}
+
+func (r *UserRepository) ReplaceUserRoles(ctx context.Context, userID uint, roleIDs []uint) error {
+	roles := []models.Role{}
+	if len(roleIDs) > 0 {
+		if err := r.db.WithContext(ctx).Find(&roles, roleIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	user := models.User{BaseModel: models.BaseModel{ID: userID}}
+	return r.db.WithContext(ctx).Model(&user).Omit("Roles.*").Association("Roles").Replace(roles)
+}
+
 var _ IUserRepository = (*UserRepository)(nil)
 var _ IBaseRepository[models.User] = (*BaseRepository[models.User])(nil)
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserSessionRepository interface {
+	Upsert(ctx context.Context, userSession *models.UserSession) error
+	GetByID(id uint) (*models.UserSession, error)
+	GetActiveByUser(userID uint, since time.Time) ([]models.UserSession, error)
+	GetSessionIDsByUser(userID uint, exceptSessionID string) ([]string, error)
+	DeleteBySessionIDs(ctx context.Context, sessionIDs []string) error
+	DeleteStaleByUser(ctx context.Context, userID uint, before time.Time) error
+}
+
+type UserSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository() IUserSessionRepository {
+	return &UserSessionRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *UserSessionRepository) Upsert(ctx context.Context, userSession *models.UserSession) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "ip_address", "user_agent", "last_seen_at"}),
+	}).Create(userSession).Error
+}
+
+func (r *UserSessionRepository) GetByID(id uint) (*models.UserSession, error) {
+	var userSession models.UserSession
+	if err := r.db.First(&userSession, id).Error; err != nil {
+		return nil, err
+	}
+	return &userSession, nil
+}
+
+func (r *UserSessionRepository) GetActiveByUser(userID uint, since time.Time) ([]models.UserSession, error) {
+	var userSessions []models.UserSession
+	err := r.db.
+		Where("user_id = ? AND last_seen_at >= ?", userID, since).
+		Order("last_seen_at desc").
+		Find(&userSessions).Error
+	return userSessions, err
+}
+
+func (r *UserSessionRepository) GetSessionIDsByUser(userID uint, exceptSessionID string) ([]string, error) {
+	var sessionIDs []string
+	query := r.db.Model(&models.UserSession{}).Where("user_id = ?", userID)
+	if exceptSessionID != "" {
+		query = query.Where("session_id <> ?", exceptSessionID)
+	}
+	err := query.Pluck("session_id", &sessionIDs).Error
+	return sessionIDs, err
+}
+
+func (r *UserSessionRepository) DeleteBySessionIDs(ctx context.Context, sessionIDs []string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("session_id IN ?", sessionIDs).Delete(&models.UserSession{}).Error
+}
+
+func (r *UserSessionRepository) DeleteStaleByUser(ctx context.Context, userID uint, before time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND last_seen_at < ?", userID, before).
+		Delete(&models.UserSession{}).Error
+}
+
+var _ IUserSessionRepository = (*UserSessionRepository)(nil)
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IWebAuthnCredentialRepository interface {
+	GetByUser(userID uint) ([]models.WebAuthnCredential, error)
+	Create(ctx context.Context, credential *models.WebAuthnCredential) error
+	RecordUse(ctx context.Context, id uint, signCount uint32, backupState bool) error
+	DeleteForUser(ctx context.Context, userID, id uint) (int64, error)
+}
+
+type WebAuthnCredentialRepository struct {
+	db *gorm.DB
+}
+
+func NewWebAuthnCredentialRepository() IWebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{db: databaseconfig.GetDB()}
+}
+
+func (r *WebAuthnCredentialRepository) GetByUser(userID uint) ([]models.WebAuthnCredential, error) {
+	var credentials []models.WebAuthnCredential
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&credentials).Error
+	return credentials, err
+}
+
+func (r *WebAuthnCredentialRepository) Create(ctx context.Context, credential *models.WebAuthnCredential) error {
+	return r.db.WithContext(ctx).Create(credential).Error
+}
+
+func (r *WebAuthnCredentialRepository) RecordUse(ctx context.Context, id uint, signCount uint32, backupState bool) error {
+	return r.db.WithContext(ctx).Model(&models.WebAuthnCredential{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"sign_count":   signCount,
+			"backup_state": backupState,
+			"last_used_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *WebAuthnCredentialRepository) DeleteForUser(ctx context.Context, userID, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.WebAuthnCredential{})
+	return result.RowsAffected, result.Error
+}
+
+var _ IWebAuthnCredentialRepository = (*WebAuthnCredentialRepository)(nil)
@@ -0,0 +1,23 @@
+package requests
+
+type (
+	APIUserCreateRequest struct {
+		Name              string `json:"name" validate:"required,min=3,max=100" label:"fields.name"`
+		Email             string `json:"email" validate:"required,email,max=100" label:"fields.email"`
+		Password          string `json:"password" validate:"required,min=8" label:"fields.password"`
+		Type              string `json:"type" validate:"required,oneof=dashboard panel" label:"fields.user_type"`
+		Status            *bool  `json:"status"`
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		RoleIDs           []uint `json:"role_ids"`
+	}
+
+	APIUserUpdateRequest struct {
+		Name              string  `json:"name" validate:"required,min=3,max=100" label:"fields.name"`
+		Email             string  `json:"email" validate:"required,email,max=100" label:"fields.email"`
+		Password          string  `json:"password" validate:"omitempty,min=8" label:"fields.password"`
+		Type              string  `json:"type" validate:"required,oneof=dashboard panel" label:"fields.user_type"`
+		Status            *bool   `json:"status"`
+		TwoFactorRequired *bool   `json:"two_factor_required"`
+		RoleIDs           *[]uint `json:"role_ids"`
+	}
+)
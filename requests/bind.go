@@ -0,0 +1,118 @@
+package requests
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/flashmessages"
+	"zatrano/pkg/i18n"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// validate, tüm istek tipleri için ortak doğrulayıcıdır. Hata alanları JSON etiketindeki
+// adla raporlanır; etiketi olmayan alanlarda Go alan adı kullanılır.
+var validate = func() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	registerTurkishRules(v)
+	return v
+}()
+
+// ErrInvalidRequestFormat, gövdesi ya da sorgusu istek tipine çözümlenemeyen istekler için döner.
+var ErrInvalidRequestFormat = apperrors.BadRequest("invalid_request_format", "Geçersiz istek formatı.")
+
+type bindKey[T any] struct{}
+
+// Bind, isteği içerik tipine göre (JSON, form ya da query) T tipine bağlar, doğrular ve
+// Get[T] ile okunmak üzere saklar. JSON bekleyen istemcilere alan bazlı doğrulama hatası
+// döner; diğerleri alan hataları ve eski girdilerle redirect adresine, boşsa isteğin
+// kendi yoluna yönlendirilir.
+func Bind[T any](redirect string) fiber.Handler {
+	return BindRedirect[T](func(c *fiber.Ctx) string {
+		if redirect == "" {
+			return c.Path()
+		}
+		return redirect
+	})
+}
+
+// BindRedirect, Bind ile aynıdır; yönlendirme adresi isteğe göre değiştiğinde
+// (ör. sorgu parametresi taşıması gerektiğinde) kullanılır.
+func BindRedirect[T any](redirect func(c *fiber.Ctx) string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req T
+		wantsJSON := apperrors.WantsJSON(c)
+		redirectPath := redirect(c)
+
+		if err := parseRequest(c, &req); err != nil {
+			if wantsJSON {
+				return ErrInvalidRequestFormat.Wrap(err)
+			}
+			_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, apperrors.Key(ErrInvalidRequestFormat))
+			return c.Redirect(redirectPath, fiber.StatusSeeOther)
+		}
+
+		if err := validate.Struct(&req); err != nil {
+			var validationErrors validator.ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				return ErrInvalidRequestFormat.Wrap(err)
+			}
+			locale := i18n.Locale(c)
+			if wantsJSON {
+				fields, _ := fieldMessages(locale, &req, validationErrors, true)
+				return apperrors.Validation(fields)
+			}
+			fields, summary := fieldMessages(locale, &req, validationErrors, false)
+			return redirectWithErrors(c, &req, fields, summary, redirectPath)
+		}
+
+		c.Locals(bindKey[T]{}, req)
+		return c.Next()
+	}
+}
+
+// Get, Bind[T] tarafından bağlanmış isteği döner.
+func Get[T any](c *fiber.Ctx) (T, bool) {
+	req, ok := c.Locals(bindKey[T]{}).(T)
+	return req, ok
+}
+
+func parseRequest(c *fiber.Ctx, out interface{}) error {
+	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead || len(c.Request().Header.ContentType()) == 0 {
+		return c.QueryParser(out)
+	}
+	return c.BodyParser(out)
+}
+
+// fieldMessages, her alan için ilk hatanın mesajını istenen dilde toplar. JSON yanıtlarında alanlar
+// istemcinin gönderdiği adlarla, formlarda şablonların kullandığı Go alan adlarıyla
+// anahtarlanır. İkinci dönüş değeri ilk hatalı alanın mesajıdır.
+func fieldMessages(locale string, req interface{}, validationErrors validator.ValidationErrors, jsonNames bool) (map[string]string, string) {
+	var summary string
+	fields := make(map[string]string, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		key := fieldErr.StructField()
+		if jsonNames {
+			key = fieldErr.Field()
+		}
+		if _, exists := fields[key]; exists {
+			continue
+		}
+		msg := message(locale, req, fieldErr)
+		fields[key] = msg
+		if summary == "" {
+			summary = msg
+		}
+	}
+	return fields, summary
+}
@@ -0,0 +1,65 @@
+package requests
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"zatrano/pkg/flashmessages"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RedirectWithInput, doğrulamadan geçmiş ancak servis katmanında reddedilen bir formu
+// kullanıcının girdileriyle birlikte yeniden göstermek için kullanılır.
+func RedirectWithInput(c *fiber.Ctx, req interface{}, message string, redirectPath string) error {
+	return redirectWithErrors(c, req, nil, message, redirectPath)
+}
+
+func redirectWithErrors(c *fiber.Ctx, req interface{}, fieldErrors map[string]string, message string, redirectPath string) error {
+	_ = flashmessages.SetFlashMessage(c, flashmessages.FlashErrorKey, message)
+	_ = flashmessages.SetFormState(c, flashmessages.FormState{
+		FieldErrors: fieldErrors,
+		OldInput:    oldInputFrom(req),
+	})
+	return c.Redirect(redirectPath, fiber.StatusSeeOther)
+}
+
+// oldInputFrom, istek struct'ındaki değerleri alan adlarıyla toplar. Adında "password"
+// veya "token" geçen alanlar session'a hiçbir zaman yazılmaz.
+func oldInputFrom(req interface{}) map[string][]string {
+	v := reflect.Indirect(reflect.ValueOf(req))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	input := make(map[string][]string, v.NumField())
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || isSecretField(field.Name) {
+			continue
+		}
+
+		value := reflect.Indirect(v.Field(i))
+		if !value.IsValid() {
+			input[field.Name] = nil
+			continue
+		}
+		if value.Kind() == reflect.Slice {
+			values := make([]string, value.Len())
+			for j := range values {
+				values[j] = fmt.Sprint(value.Index(j).Interface())
+			}
+			input[field.Name] = values
+			continue
+		}
+		input[field.Name] = []string{fmt.Sprint(value.Interface())}
+	}
+	return input
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "password") || strings.Contains(name, "token")
+}
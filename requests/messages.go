@@ -0,0 +1,95 @@
+package requests
+
+import (
+	"reflect"
+	"strings"
+
+	"zatrano/pkg/i18n"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Doğrulama mesajları i18n kataloğundaki "validation.<kural>" anahtarlarından gelir;
+// "validation.min.number" gibi alan türüyle daraltılmış anahtarlar önce denenir. {field}
+// alanın `label` etiketindeki anahtarın çevirisiyle, {param} kuralın parametresiyle doldurulur.
+const (
+	defaultFieldLabel = "validation.field"
+	defaultMessage    = "validation.default"
+)
+
+// message, bir doğrulama hatasının kullanıcıya gösterilecek metnini istenen dilde üretir.
+// Alanın `message:"eqfield:validation.passwords_mismatch|..."` etiketi katalogdan önce gelir.
+func message(locale string, req interface{}, fieldErr validator.FieldError) string {
+	label := i18n.T(locale, defaultFieldLabel)
+	if field, ok := structField(req, fieldErr.StructNamespace()); ok {
+		if key, found := tagMessage(field.Tag.Get("message"), fieldErr.Tag()); found {
+			return i18n.T(locale, key)
+		}
+		if l := field.Tag.Get("label"); l != "" {
+			label = i18n.T(locale, l)
+		}
+	}
+
+	args := []interface{}{"field", label, "param", fieldErr.Param()}
+	for _, key := range []string{
+		"validation." + fieldErr.Tag() + "." + kindGroup(fieldErr.Kind()),
+		"validation." + fieldErr.Tag(),
+	} {
+		if msg, ok := i18n.Lookup(locale, key, args...); ok {
+			return msg
+		}
+	}
+	return i18n.T(locale, defaultMessage, args...)
+}
+
+func tagMessage(tag, rule string) (string, bool) {
+	if tag == "" {
+		return "", false
+	}
+	for _, entry := range strings.Split(tag, "|") {
+		name, msg, found := strings.Cut(entry, ":")
+		if found && strings.TrimSpace(name) == rule {
+			return strings.TrimSpace(msg), true
+		}
+	}
+	return "", false
+}
+
+// structField, "LoginRequest.Email" ya da "Req.Items[0].Name" biçimindeki ad yolunu
+// izleyerek alanın tanımını bulur.
+func structField(req interface{}, namespace string) (reflect.StructField, bool) {
+	t := reflect.TypeOf(req)
+	parts := strings.Split(namespace, ".")
+	if len(parts) < 2 {
+		return reflect.StructField{}, false
+	}
+
+	var field reflect.StructField
+	for _, part := range parts[1:] {
+		for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+		name, _, _ := strings.Cut(part, "[")
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return reflect.StructField{}, false
+		}
+		field, t = f, f.Type
+	}
+	return field, true
+}
+
+func kindGroup(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "slice"
+	}
+	return "string"
+}
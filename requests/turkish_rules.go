@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"reflect"
+
+	"zatrano/pkg/openapi"
+	"zatrano/pkg/trvalidation"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Türkiye'ye özgü kurallar ortak doğrulayıcıya kaydedilir. tr_mobile, tr_iban ve tr_name
+// geçerli değeri yerinde normalize eder; bu yüzden Bind gibi struct'ın adresiyle
+// doğrulama yapan çağrılarda alan E.164 / boşluksuz IBAN biçiminde kalır. Mesajlar
+// i18n kataloğundaki "validation.<kural>" anahtarlarındadır.
+var turkishRules = map[string]struct {
+	fn     validator.Func
+	schema openapi.TagRule
+}{
+	"tckn": {
+		fn:     func(fl validator.FieldLevel) bool { return trvalidation.IsTCKN(fl.Field().String()) },
+		schema: func(s *openapi.Schema, _ reflect.Kind, _ string) { s.Pattern = `^[1-9][0-9]{10}$` },
+	},
+	"vkn": {
+		fn:     func(fl validator.FieldLevel) bool { return trvalidation.IsVKN(fl.Field().String()) },
+		schema: func(s *openapi.Schema, _ reflect.Kind, _ string) { s.Pattern = `^[0-9]{10}$` },
+	},
+	"tr_mobile": {
+		fn: func(fl validator.FieldLevel) bool {
+			normalized, ok := trvalidation.NormalizeMobile(fl.Field().String())
+			if ok {
+				setString(fl.Field(), normalized)
+			}
+			return ok
+		},
+		schema: func(s *openapi.Schema, _ reflect.Kind, _ string) { s.Pattern = `^\+905[0-9]{9}$` },
+	},
+	"tr_iban": {
+		fn: func(fl validator.FieldLevel) bool {
+			if !trvalidation.IsTRIBAN(fl.Field().String()) {
+				return false
+			}
+			setString(fl.Field(), trvalidation.NormalizeIBAN(fl.Field().String()))
+			return true
+		},
+		schema: func(s *openapi.Schema, _ reflect.Kind, _ string) { s.Pattern = `^TR[0-9]{24}$` },
+	},
+	"tr_name": {
+		fn: func(fl validator.FieldLevel) bool {
+			if !trvalidation.IsName(fl.Field().String()) {
+				return false
+			}
+			setString(fl.Field(), trvalidation.NormalizeName(fl.Field().String()))
+			return true
+		},
+	},
+}
+
+func registerTurkishRules(v *validator.Validate) {
+	for tag, rule := range turkishRules {
+		if err := v.RegisterValidation(tag, rule.fn); err != nil {
+			panic(err)
+		}
+		if rule.schema != nil {
+			openapi.RegisterTagRule(tag, rule.schema)
+		}
+	}
+}
+
+func setString(field reflect.Value, value string) {
+	if field.Kind() == reflect.String && field.CanSet() {
+		field.SetString(value)
+	}
+}
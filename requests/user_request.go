@@ -0,0 +1,22 @@
+package requests
+
+type (
+	UserCreateRequest struct {
+		Name     string `form:"name" validate:"required,min=3,max=100" label:"fields.name"`
+		Email    string `form:"email" validate:"required,max=100" label:"fields.account_name"`
+		Password string `form:"password" validate:"required,min=8" label:"fields.password"`
+		Status   string `form:"status"`
+		Type     string `form:"type" validate:"required,oneof=dashboard panel" label:"fields.user_type"`
+		RoleIDs  []uint `form:"role_ids"`
+	}
+
+	UserUpdateRequest struct {
+		Name              string `form:"name" validate:"required,min=3,max=100" label:"fields.name"`
+		Email             string `form:"email" validate:"required,max=100" label:"fields.account_name"`
+		Password          string `form:"password" validate:"omitempty,min=8" label:"fields.password"`
+		Status            string `form:"status"`
+		Type              string `form:"type" validate:"required,oneof=dashboard panel" label:"fields.user_type"`
+		TwoFactorRequired string `form:"two_factor_required"`
+		RoleIDs           []uint `form:"role_ids"`
+	}
+)
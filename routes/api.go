@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"zatrano/configs/envconfig"
+	handlers "zatrano/handlers/api"
+	"zatrano/middlewares"
+	"zatrano/models"
+	"zatrano/pkg/openapi"
+	"zatrano/requests"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func registerAPIRoutes(app *fiber.App) {
+	app.Get("/api/openapi.json", openapi.SpecHandler(handlers.Spec))
+	if !envconfig.IsProduction() {
+		app.Get("/api/docs", openapi.DocsHandler("/api/openapi.json"))
+	}
+
+	v1 := app.Group("/api/v1", middlewares.TokenAuth)
+
+	userHandler := handlers.NewUserHandler()
+	v1.Get("/users", middlewares.Can(models.PermissionUsersView), userHandler.ListUsers)
+	v1.Post("/users", middlewares.Can(models.PermissionUsersCreate), requests.Bind[requests.APIUserCreateRequest](""), userHandler.CreateUser)
+	v1.Get("/users/:id", middlewares.Can(models.PermissionUsersView), userHandler.ShowUser)
+	v1.Put("/users/:id", middlewares.Can(models.PermissionUsersUpdate), requests.Bind[requests.APIUserUpdateRequest](""), userHandler.UpdateUser)
+	v1.Delete("/users/:id", middlewares.Can(models.PermissionUsersDelete), userHandler.DeleteUser)
+}
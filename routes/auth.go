@@ -14,20 +14,49 @@ func registerAuthRoutes(app *fiber.App) {
 	authGroup := app.Group("/auth")
 
 	authGroup.Get("/login", authHandler.ShowLogin)
-	authGroup.Post("/login", middlewares.GuestMiddleware, requests.ValidateLoginRequest, authHandler.Login)
+	authGroup.Post("/login", middlewares.GuestMiddleware, requests.Bind[requests.LoginRequest]("/auth/login"), authHandler.Login)
+	authGroup.Post("/magic-link/request", middlewares.GuestMiddleware, requests.Bind[requests.MagicLinkRequest]("/auth/login"), authHandler.RequestMagicLink)
+	authGroup.Get("/magic-link", middlewares.GuestMiddleware, authHandler.ShowMagicLink)
+	authGroup.Post("/magic-link", middlewares.GuestMiddleware, authHandler.MagicLinkLogin)
+
+	authGroup.Post("/passkey/login/begin", middlewares.GuestMiddleware, authHandler.BeginPasskeyLogin)
+	authGroup.Post("/passkey/login/finish", middlewares.GuestMiddleware, authHandler.FinishPasskeyLogin)
+
+	authGroup.Get("/2fa", middlewares.GuestMiddleware, authHandler.ShowTwoFactorChallenge)
+	authGroup.Post("/2fa", middlewares.GuestMiddleware, authHandler.VerifyTwoFactorChallenge)
+	authGroup.Get("/2fa/setup", middlewares.AuthMiddleware, authHandler.ShowTwoFactorSetup)
+	authGroup.Post("/2fa/setup", middlewares.AuthMiddleware, authHandler.EnableTwoFactor)
+	authGroup.Post("/2fa/disable", middlewares.AuthMiddleware, authHandler.DisableTwoFactor)
+	authGroup.Post("/2fa/recovery-codes", middlewares.AuthMiddleware, authHandler.RegenerateRecoveryCodes)
 
 	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
 	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
-	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, requests.ValidateUpdatePasswordRequest, authHandler.UpdatePassword)
+	authGroup.Post("/profile/locale", middlewares.AuthMiddleware, authHandler.UpdateLocale)
+	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, requests.Bind[requests.UpdatePasswordRequest]("/auth/profile"), authHandler.UpdatePassword)
+	authGroup.Post("/profile/devices/revoke-all", middlewares.AuthMiddleware, authHandler.RevokeAllDevices)
+	authGroup.Post("/profile/devices/:id/revoke", middlewares.AuthMiddleware, authHandler.RevokeDevice)
+	authGroup.Post("/profile/passkeys/register/begin", middlewares.AuthMiddleware, authHandler.BeginPasskeyRegistration)
+	authGroup.Post("/profile/passkeys/register/finish", middlewares.AuthMiddleware, authHandler.FinishPasskeyRegistration)
+	authGroup.Post("/profile/passkeys/:id/revoke", middlewares.AuthMiddleware, authHandler.RevokePasskey)
+	authGroup.Post("/profile/api-tokens", middlewares.AuthMiddleware, authHandler.CreateAPIToken)
+	authGroup.Post("/profile/api-tokens/:id/revoke", middlewares.AuthMiddleware, authHandler.RevokeAPIToken)
+	authGroup.Get("/profile/identities/:provider/link", middlewares.AuthMiddleware, authHandler.LinkIdentity)
+	authGroup.Post("/profile/identities/:id/unlink", middlewares.AuthMiddleware, authHandler.UnlinkIdentity)
 	authGroup.Get("/register", authHandler.ShowRegister)
-	authGroup.Post("/register", middlewares.GuestMiddleware, requests.ValidateRegisterRequest, authHandler.Register)
+	authGroup.Post("/register", middlewares.GuestMiddleware, requests.Bind[requests.RegisterRequest]("/auth/register"), authHandler.Register)
 	authGroup.Get("/forgot-password", authHandler.ShowForgotPassword)
-	authGroup.Post("/forgot-password", middlewares.GuestMiddleware, requests.ValidateForgotPasswordRequest, authHandler.ForgotPassword)
+	authGroup.Post("/forgot-password", middlewares.GuestMiddleware, requests.Bind[requests.ForgotPasswordRequest]("/auth/forgot-password"), authHandler.ForgotPassword)
 	authGroup.Get("/reset-password", authHandler.ShowResetPassword)
-	authGroup.Post("/reset-password", middlewares.GuestMiddleware, requests.ValidateResetPasswordRequest, authHandler.ResetPassword)
+	authGroup.Post("/reset-password", middlewares.GuestMiddleware, requests.BindRedirect[requests.ResetPasswordRequest](handlers.ResetPasswordRedirect), authHandler.ResetPassword)
 	authGroup.Get("/verify-email", authHandler.VerifyEmail)
+	authGroup.Get("/unlock", authHandler.ShowUnlockAccount)
+	authGroup.Post("/unlock", authHandler.UnlockAccount)
 	authGroup.Get("/resend-verification", authHandler.ShowResendVerification)
-	authGroup.Post("/resend-verification", requests.ValidateResendVerificationRequest, authHandler.ResendVerification)
-	authGroup.Get("/google/login", handlers.GoogleLogin)
-	authGroup.Get("/google/callback", handlers.GoogleCallback)
+	authGroup.Post("/resend-verification", requests.Bind[requests.ResendVerificationRequest]("/auth/resend-verification"), authHandler.ResendVerification)
+	authGroup.Get("/link", middlewares.GuestMiddleware, authHandler.ShowIdentityLink)
+	authGroup.Post("/link", middlewares.GuestMiddleware, authHandler.ConfirmIdentityLinkWithPassword)
+	authGroup.Post("/link/email", middlewares.GuestMiddleware, authHandler.SendIdentityLinkEmail)
+	authGroup.Get("/link/confirm", authHandler.ConfirmIdentityLinkWithToken)
+	authGroup.Get("/:provider/login", middlewares.GuestMiddleware, authHandler.OAuthLogin)
+	authGroup.Get("/:provider/callback", authHandler.OAuthCallback)
 }
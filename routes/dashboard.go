@@ -4,6 +4,7 @@ import (
 	handlers "zatrano/handlers/dashboard"
 	"zatrano/middlewares"
 	"zatrano/models"
+	"zatrano/requests"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,16 +15,32 @@ func registerDashboardRoutes(app *fiber.App) {
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
 		middlewares.TypeMiddleware(models.Dashboard),
+		middlewares.TwoFactorSetupMiddleware,
 	)
 
 	dashboardHomeHandler := handlers.NewDashboardHomeHandler()
 	dashboardGroup.Get("/home", dashboardHomeHandler.HomePage)
 
 	userHandler := handlers.NewUserHandler()
-	dashboardGroup.Get("/users", userHandler.ListUsers)
-	dashboardGroup.Get("/users/create", userHandler.ShowCreateUser)
-	dashboardGroup.Post("/users/create", userHandler.CreateUser)
-	dashboardGroup.Get("/users/update/:id", userHandler.ShowUpdateUser)
-	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
-	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
+	dashboardGroup.Get("/users", middlewares.Can(models.PermissionUsersView), userHandler.ListUsers)
+	dashboardGroup.Get("/users/create", middlewares.Can(models.PermissionUsersCreate), userHandler.ShowCreateUser)
+	dashboardGroup.Post("/users/create", middlewares.Can(models.PermissionUsersCreate), requests.Bind[requests.UserCreateRequest](""), userHandler.CreateUser)
+	dashboardGroup.Get("/users/update/:id", middlewares.Can(models.PermissionUsersUpdate), userHandler.ShowUpdateUser)
+	dashboardGroup.Post("/users/update/:id", middlewares.Can(models.PermissionUsersUpdate), requests.Bind[requests.UserUpdateRequest](""), userHandler.UpdateUser)
+	dashboardGroup.Post("/users/require-2fa", middlewares.Can(models.PermissionUsersUpdate), userHandler.RequireTwoFactor)
+	dashboardGroup.Post("/users/:id/unlock", middlewares.Can(models.PermissionUsersUpdate), userHandler.UnlockUser)
+	dashboardGroup.Post("/users/:id/sessions/terminate", middlewares.Can(models.PermissionUsersUpdate), userHandler.TerminateUserSessions)
+	dashboardGroup.Delete("/users/delete/:id", middlewares.Can(models.PermissionUsersDelete), userHandler.DeleteUser)
+	dashboardGroup.Get("/users/trash", middlewares.Can(models.PermissionUsersDelete), userHandler.ListTrashedUsers)
+	dashboardGroup.Post("/users/trash/:id/restore", middlewares.Can(models.PermissionUsersDelete), userHandler.RestoreUser)
+	dashboardGroup.Post("/users/trash/:id/delete", middlewares.Can(models.PermissionUsersDelete), userHandler.ForceDeleteUser)
+
+	roleHandler := handlers.NewRoleHandler()
+	rolesGroup := dashboardGroup.Group("/roles", middlewares.Can(models.PermissionRolesManage))
+	rolesGroup.Get("/", roleHandler.ListRoles)
+	rolesGroup.Get("/create", roleHandler.ShowCreateRole)
+	rolesGroup.Post("/create", roleHandler.CreateRole)
+	rolesGroup.Get("/update/:id", roleHandler.ShowUpdateRole)
+	rolesGroup.Post("/update/:id", roleHandler.UpdateRole)
+	rolesGroup.Delete("/delete/:id", roleHandler.DeleteRole)
 }
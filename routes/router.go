@@ -1,7 +1,7 @@
 package routes
 
 import (
-	"davet.link/middlewares"
+	"zatrano/middlewares"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
@@ -23,4 +23,5 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 	registerAuthRoutes(app)
 	registerDashboardRoutes(app)
 	registerPanelRoutes(app)
+	registerAPIRoutes(app)
 }
@@ -7,5 +7,7 @@ import (
 )
 
 func registerWebsiteRoutes(app *fiber.App) {
-	app.Get("/", handlers.NewWebsiteHandler().ShowHomePage)
+	websiteHandler := handlers.NewWebsiteHandler()
+	app.Get("/", websiteHandler.ShowHomePage)
+	app.Get("/locale/:locale", websiteHandler.SwitchLocale)
 }
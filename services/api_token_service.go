@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/ttlcache"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAPITokenInvalid      = apperrors.Unauthorized("api_token_invalid", "API tokenı geçersiz veya süresi dolmuş.")
+	ErrAPITokenNameRequired = apperrors.Unprocessable("api_token_name_required", "Token adı zorunludur.")
+	ErrAPITokenScope        = apperrors.Unprocessable("api_token_scope", "Token yalnızca sahip olduğunuz yetkileri içerebilir.")
+	ErrAPITokenExpiry       = apperrors.Unprocessable("api_token_expiry", "Geçersiz token süresi.")
+	ErrAPITokenNotFound     = apperrors.NotFound("api_token_not_found", "API tokenı bulunamadı.")
+	ErrAPITokenGeneric      = apperrors.Internal("api_token_failed", "API tokenı işlemi sırasında bir hata oluştu.")
+)
+
+const (
+	// APITokenPrefix, tokenların loglarda ve gizli anahtar taramalarında tanınmasını sağlar.
+	APITokenPrefix      = "ztr_"
+	apiTokenBytes       = 32
+	apiTokenDisplayLen  = 8
+	maxAPITokenNameSize = 100
+)
+
+// APITokenExpiryDays, profil sayfasında sunulan geçerlilik süreleridir (gün).
+var APITokenExpiryDays = []int{7, 30, 90, 365}
+
+// apiTokenTouches, son kullanım bilgisinin her istekte yazılmasını engeller.
+var apiTokenTouches = ttlcache.New[uint, struct{}](
+	time.Duration(envconfig.GetEnvAsInt("API_TOKEN_TOUCH_INTERVAL_SECONDS", 60)) * time.Second,
+)
+
+type IAPITokenService interface {
+	Create(ctx context.Context, user *models.User, name string, scopes []string, expiresInDays int) (string, *models.APIToken, error)
+	Authenticate(ctx context.Context, plainToken, ip string) (*models.User, *models.APIToken, error)
+	GetTokens(userID uint) ([]models.APIToken, error)
+	Revoke(ctx context.Context, userID, id uint) (*models.APIToken, error)
+	RevokeAll(ctx context.Context, userID uint) (int, error)
+	AvailableScopes(user *models.User) []string
+}
+
+type APITokenService struct {
+	repo repositories.IAPITokenRepository
+	auth IAuthService
+}
+
+func NewAPITokenService() IAPITokenService {
+	return &APITokenService{
+		repo: repositories.NewAPITokenRepository(),
+		auth: NewAuthService(),
+	}
+}
+
+// AvailableScopes, kullanıcının rolleri üzerinden sahip olduğu yetkilerdir; token bunların dışına çıkamaz.
+func (s *APITokenService) AvailableScopes(user *models.User) []string {
+	seen := make(map[string]struct{})
+	for _, role := range user.Roles {
+		for _, permission := range role.Permissions {
+			seen[permission.Name] = struct{}{}
+		}
+	}
+	scopes := make([]string, 0, len(seen))
+	for scope := range seen {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+func (s *APITokenService) Create(ctx context.Context, user *models.User, name string, scopes []string, expiresInDays int) (string, *models.APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, ErrAPITokenNameRequired
+	}
+	if len([]rune(name)) > maxAPITokenNameSize {
+		name = string([]rune(name)[:maxAPITokenNameSize])
+	}
+
+	maxDays := envconfig.GetEnvAsInt("API_TOKEN_MAX_TTL_DAYS", 365)
+	if expiresInDays <= 0 || expiresInDays > maxDays {
+		return "", nil, ErrAPITokenExpiry
+	}
+
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		if !user.HasPermission(scope) {
+			return "", nil, ErrAPITokenScope
+		}
+		granted = append(granted, scope)
+	}
+	sort.Strings(granted)
+
+	raw := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		logconfig.Log.Error("API tokenı üretilemedi", zap.Error(err))
+		return "", nil, ErrAPITokenGeneric
+	}
+	secret := base64.RawURLEncoding.EncodeToString(raw)
+	plainToken := APITokenPrefix + secret
+
+	token := &models.APIToken{
+		UserID:    user.ID,
+		Name:      name,
+		TokenHash: hashAuthToken(plainToken),
+		Prefix:    APITokenPrefix + secret[:apiTokenDisplayLen],
+		Scopes:    strings.Join(granted, " "),
+		ExpiresAt: time.Now().UTC().AddDate(0, 0, expiresInDays),
+	}
+	if err := s.repo.Create(ctx, token); err != nil {
+		logconfig.Log.Error("API tokenı kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return "", nil, ErrAPITokenGeneric
+	}
+
+	logconfig.Log.Info("API tokenı oluşturuldu",
+		zap.Uint("user_id", user.ID),
+		zap.Uint("token_id", token.ID),
+		zap.Strings("scopes", granted))
+	return plainToken, token, nil
+}
+
+func (s *APITokenService) Authenticate(ctx context.Context, plainToken, ip string) (*models.User, *models.APIToken, error) {
+	if !strings.HasPrefix(plainToken, APITokenPrefix) {
+		return nil, nil, ErrAPITokenInvalid
+	}
+
+	token, err := s.repo.FindByHash(hashAuthToken(plainToken))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logconfig.Log.Error("API tokenı sorgulanamadı", zap.Error(err))
+			return nil, nil, ErrAPITokenGeneric
+		}
+		return nil, nil, ErrAPITokenInvalid
+	}
+	if token.Expired(time.Now().UTC()) {
+		return nil, nil, ErrAPITokenInvalid
+	}
+
+	user, err := s.auth.GetAuthenticatedUser(token.UserID)
+	if err != nil {
+		return nil, nil, ErrAPITokenInvalid
+	}
+	if !user.Status {
+		return nil, nil, ErrUserInactive
+	}
+
+	if _, ok := apiTokenTouches.Get(token.ID); !ok {
+		if err := s.repo.Touch(ctx, token.ID, ip); err != nil {
+			logconfig.Log.Warn("API tokenı kullanım bilgisi güncellenemedi", zap.Uint("token_id", token.ID), zap.Error(err))
+		} else {
+			apiTokenTouches.Set(token.ID, struct{}{})
+		}
+	}
+	return user, token, nil
+}
+
+func (s *APITokenService) GetTokens(userID uint) ([]models.APIToken, error) {
+	tokens, err := s.repo.GetByUser(userID)
+	if err != nil {
+		logconfig.Log.Error("API tokenları alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrAPITokenGeneric
+	}
+	return tokens, nil
+}
+
+func (s *APITokenService) Revoke(ctx context.Context, userID, id uint) (*models.APIToken, error) {
+	tokens, err := s.GetTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *models.APIToken
+	for i := range tokens {
+		if tokens[i].ID == id {
+			target = &tokens[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, ErrAPITokenNotFound
+	}
+
+	deleted, err := s.repo.DeleteForUser(ctx, userID, id)
+	if err != nil {
+		logconfig.Log.Error("API tokenı silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrAPITokenGeneric
+	}
+	if deleted == 0 {
+		return nil, ErrAPITokenNotFound
+	}
+	apiTokenTouches.Delete(id)
+	logconfig.Log.Info("API tokenı iptal edildi", zap.Uint("user_id", userID), zap.Uint("token_id", id))
+	return target, nil
+}
+
+// RevokeAll kullanıcının tüm API tokenlarını siler ve silinen token sayısını döner.
+func (s *APITokenService) RevokeAll(ctx context.Context, userID uint) (int, error) {
+	deleted, err := s.repo.DeleteAllForUser(ctx, userID)
+	if err != nil {
+		logconfig.Log.Error("API tokenları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, ErrAPITokenGeneric
+	}
+	if deleted > 0 {
+		logconfig.Log.Info("Kullanıcının tüm API tokenları iptal edildi", zap.Uint("user_id", userID), zap.Int64("count", deleted))
+	}
+	return int(deleted), nil
+}
+
+var _ IAPITokenService = (*APITokenService)(nil)
@@ -2,14 +2,14 @@ package services
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 
 	"zatrano/configs/logconfig"
 	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/i18n"
 	"zatrano/repositories"
 
 	"go.uber.org/zap"
@@ -17,44 +17,51 @@ import (
 	"gorm.io/gorm"
 )
 
-type ServiceError string
-
-func (e ServiceError) Error() string {
-	return string(e)
-}
-
-const (
-	ErrInvalidCredentials       ServiceError = "geçersiz kimlik bilgileri"
-	ErrUserNotFound             ServiceError = "kullanıcı bulunamadı"
-	ErrUserInactive             ServiceError = "kullanıcı aktif değil"
-	ErrCurrentPasswordIncorrect ServiceError = "mevcut şifre hatalı"
-	ErrPasswordTooShort         ServiceError = "yeni şifre en az 6 karakter olmalıdır"
-	ErrPasswordSameAsOld        ServiceError = "yeni şifre mevcut şifre ile aynı olamaz"
-	ErrAuthGeneric              ServiceError = "kimlik doğrulaması sırasında bir hata oluştu"
-	ErrProfileGeneric           ServiceError = "profil bilgileri alınırken hata"
-	ErrUpdatePasswordGeneric    ServiceError = "şifre güncellenirken bir hata oluştu"
-	ErrHashingFailed            ServiceError = "yeni şifre oluşturulurken hata"
-	ErrDatabaseUpdateFailed     ServiceError = "veritabanı güncellemesi başarısız oldu"
+var (
+	ErrInvalidCredentials       = apperrors.Unauthorized("invalid_credentials", "Kullanıcı adı veya şifre hatalı.")
+	ErrUserNotFound             = apperrors.NotFound("user_not_found", "Kullanıcı bulunamadı.")
+	ErrUserInactive             = apperrors.Forbidden("user_inactive", "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin.")
+	ErrCurrentPasswordIncorrect = apperrors.Unprocessable("current_password_incorrect", "Mevcut şifreniz hatalı.")
+	ErrPasswordTooShort         = apperrors.Unprocessable("password_too_short", "Yeni şifre en az 6 karakter olmalıdır.")
+	ErrPasswordSameAsOld        = apperrors.Unprocessable("password_same_as_old", "Yeni şifre eski şifre ile aynı olamaz.")
+	ErrAuthGeneric              = apperrors.Internal("auth_failed", "Kimlik doğrulaması sırasında bir hata oluştu.")
+	ErrProfileGeneric           = apperrors.Internal("profile_failed", "Profil bilgileri alınırken bir hata oluştu.")
+	ErrUpdatePasswordGeneric    = apperrors.Internal("password_update_failed", "Şifre güncellenirken bir hata oluştu.")
+	ErrHashingFailed            = apperrors.Internal("password_hash_failed", "Yeni şifre oluşturulurken bir hata oluştu.")
+	ErrDatabaseUpdateFailed     = apperrors.Internal("database_update_failed", "Veritabanı güncellemesi başarısız oldu.")
+	ErrPasswordRequired         = apperrors.Unprocessable("password_required", "Şifre alanı boş olamaz.")
+	ErrEmailNotVerified         = apperrors.Forbidden("email_not_verified", "E-posta adresiniz doğrulanmamış. Lütfen önce e-posta adresinizi doğrulayın.")
+	ErrLocaleUnsupported        = apperrors.BadRequest("locale_unsupported", "Desteklenmeyen dil seçimi.")
 )
 
 type IAuthService interface {
-	Authenticate(email, password string) (*models.User, error)
+	Authenticate(email, password, ip string) (*models.User, error)
 	GetUserProfile(id uint) (*models.User, error)
+	GetAuthenticatedUser(id uint) (*models.User, error)
 	UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error
+	UpdateLocale(ctx context.Context, userID uint, locale string) (string, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	SendPasswordResetLink(email string) error
-	ResetPassword(token, newPassword string) error
+	ResetPassword(token, newPassword string) (uint, error)
 	VerifyEmail(token string) error
+	SendVerificationLink(user *models.User) error
 	ResendVerificationLink(email string) error
-	FindOrCreateUser(user models.User) (*models.User, error)
+	SendMagicLink(email string) error
+	LoginWithMagicLink(token string) (*models.User, error)
 }
 
 type AuthService struct {
-	repo repositories.IAuthRepository
+	repo     repositories.IAuthRepository
+	tokens   ITokenService
+	throttle ILoginThrottleService
 }
 
 func NewAuthService() IAuthService {
-	return &AuthService{repo: repositories.NewAuthRepository()}
+	return &AuthService{
+		repo:     repositories.NewAuthRepository(),
+		tokens:   NewTokenService(),
+		throttle: NewLoginThrottleService(),
+	}
 }
 
 func (s *AuthService) logAuthSuccess(email string, userID uint) {
@@ -111,9 +118,24 @@ func (s *AuthService) hashPassword(password string) (string, error) {
 	return string(hashedPassword), nil
 }
 
-func (s *AuthService) Authenticate(email, password string) (*models.User, error) {
+func (s *AuthService) Authenticate(email, password, ip string) (*models.User, error) {
+	ctx := context.Background()
+	if err := s.throttle.Check(ctx, email, ip); err != nil {
+		s.logWarn("Giriş denemesi engellendi",
+			zap.String("email", email),
+			zap.String("ip", ip),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
 	user, err := s.getUserByEmail(email)
 	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			if lockErr := s.throttle.RegisterFailure(ctx, email, ip, nil); lockErr != nil {
+				return nil, lockErr
+			}
+		}
 		return nil, err
 	}
 
@@ -130,9 +152,13 @@ func (s *AuthService) Authenticate(email, password string) (*models.User, error)
 			zap.String("email", email),
 			zap.Uint("user_id", user.ID),
 		)
+		if lockErr := s.throttle.RegisterFailure(ctx, email, ip, user); lockErr != nil {
+			return nil, lockErr
+		}
 		return nil, ErrInvalidCredentials
 	}
 
+	s.throttle.RegisterSuccess(ctx, email)
 	s.logAuthSuccess(email, user.ID)
 	return user, nil
 }
@@ -141,6 +167,19 @@ func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
 	return s.getUserByID(id)
 }
 
+func (s *AuthService) GetAuthenticatedUser(id uint) (*models.User, error) {
+	if user, ok := cachedUser(id); ok {
+		return user, nil
+	}
+
+	user, err := s.getUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	cacheUser(user)
+	return user, nil
+}
+
 func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPass, newPassword string) error {
 	user, err := s.getUserByID(userID)
 	if err != nil {
@@ -173,18 +212,37 @@ func (s *AuthService) UpdatePassword(ctx context.Context, userID uint, currentPa
 		s.logDBError("Kullanıcı güncelleme", err, zap.Uint("user_id", userID))
 		return ErrDatabaseUpdateFailed
 	}
+	InvalidateUserCache(userID)
 
 	logconfig.Log.Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
 	return nil
 }
 
+// UpdateLocale, kullanıcının dil tercihini kaydeder ve normalize edilmiş dil kodunu döner.
+func (s *AuthService) UpdateLocale(ctx context.Context, userID uint, locale string) (string, error) {
+	normalized, ok := i18n.Normalize(locale)
+	if !ok {
+		return "", ErrLocaleUnsupported
+	}
+
+	if err := s.repo.UpdateUserColumns(ctx, userID, map[string]interface{}{
+		"locale": normalized,
+	}); err != nil {
+		s.logDBError("Dil tercihi güncelleme", err, zap.Uint("user_id", userID))
+		return "", ErrDatabaseUpdateFailed
+	}
+	InvalidateUserCache(userID)
+
+	return normalized, nil
+}
+
 func (s *AuthService) CreateUser(ctx context.Context, user *models.User) error {
 	if user.Password == "" {
-		return errors.New("şifre alanı boş olamaz")
+		return ErrPasswordRequired
 	}
 	if err := user.SetPassword(user.Password); err != nil {
 		logconfig.Log.Error("Şifre oluşturulamadı", zap.Error(err))
-		return errors.New("şifre oluşturulurken hata oluştu")
+		return ErrHashingFailed.Wrap(err)
 	}
 	return s.repo.CreateUser(ctx, user)
 }
@@ -198,15 +256,11 @@ func (s *AuthService) SendPasswordResetLink(email string) error {
 		return ErrAuthGeneric
 	}
 
-	// Generate reset token
-	resetToken := generateToken() // Replace with actual token generation logic
-	user.ResetToken = resetToken
-
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
-		return ErrDatabaseUpdateFailed
+	resetToken, err := s.tokens.Issue(context.Background(), user.ID, models.TokenPurposePasswordReset, "")
+	if err != nil {
+		return ErrAuthGeneric
 	}
 
-	// Send reset email
 	mailService := NewMailService()
 	resetLink := os.Getenv("APP_BASE_URL") + "/auth/reset-password?token=" + resetToken
 	emailBody := "Şifrenizi sıfırlamak için aşağıdaki bağlantıya tıklayın: " + resetLink
@@ -218,47 +272,67 @@ func (s *AuthService) SendPasswordResetLink(email string) error {
 	return nil
 }
 
-func (s *AuthService) ResetPassword(token, newPassword string) error {
-	user, err := s.repo.FindUserByResetToken(token)
+// ResetPassword, token'ı tüketip parolayı değiştirir ve parolası sıfırlanan kullanıcının
+// kimliğini döner; çağıran taraf bu kullanıcının açık oturumlarını kapatmalıdır.
+func (s *AuthService) ResetPassword(token, newPassword string) (uint, error) {
+	ctx := context.Background()
+	authToken, err := s.tokens.Consume(ctx, token, models.TokenPurposePasswordReset)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrUserNotFound
+		if errors.Is(err, ErrTokenInvalid) {
+			return 0, ErrTokenInvalid
 		}
-		return ErrAuthGeneric
+		return 0, ErrAuthGeneric
 	}
 
-	if err := user.SetPassword(newPassword); err != nil {
-		return ErrHashingFailed
+	hashedPassword, err := s.hashPassword(newPassword)
+	if err != nil {
+		return 0, ErrHashingFailed
 	}
 
-	user.ResetToken = "" // Clear the token
-
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
-		return ErrDatabaseUpdateFailed
+	if err := s.repo.UpdateUserColumns(ctx, authToken.UserID, map[string]interface{}{
+		"password": hashedPassword,
+	}); err != nil {
+		return 0, ErrDatabaseUpdateFailed
 	}
+	InvalidateUserCache(authToken.UserID)
 
-	return nil
+	logconfig.Log.Info("Parola sıfırlandı", zap.Uint("user_id", authToken.UserID))
+	return authToken.UserID, nil
 }
 
 func (s *AuthService) VerifyEmail(token string) error {
-	user, err := s.repo.FindUserByVerificationToken(token)
+	ctx := context.Background()
+	authToken, err := s.tokens.Consume(ctx, token, models.TokenPurposeEmailVerification)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return ErrUserNotFound
+		if errors.Is(err, ErrTokenInvalid) {
+			return ErrTokenInvalid
 		}
 		return ErrAuthGeneric
 	}
 
-	user.EmailVerified = true
-	user.VerificationToken = "" // Clear the token
-
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
+	if err := s.repo.UpdateUserColumns(ctx, authToken.UserID, map[string]interface{}{
+		"email_verified": true,
+	}); err != nil {
 		return ErrDatabaseUpdateFailed
 	}
+	InvalidateUserCache(authToken.UserID)
 
 	return nil
 }
 
+func (s *AuthService) SendVerificationLink(user *models.User) error {
+	verificationToken, err := s.tokens.Issue(context.Background(), user.ID, models.TokenPurposeEmailVerification, "")
+	if err != nil {
+		return ErrAuthGeneric
+	}
+
+	mailService := NewMailService()
+	baseURL := os.Getenv("APP_BASE_URL")
+	verificationLink := baseURL + "/auth/verify-email?token=" + verificationToken
+	emailBody := "Lütfen email adresinizi doğrulamak için aşağıdaki bağlantıya tıklayın: " + verificationLink
+	return mailService.SendMail(user.Email, "Email Doğrulama", emailBody)
+}
+
 func (s *AuthService) ResendVerificationLink(email string) error {
 	user, err := s.repo.FindUserByEmail(email)
 	if err != nil {
@@ -270,38 +344,70 @@ func (s *AuthService) ResendVerificationLink(email string) error {
 	if user.EmailVerified {
 		return nil
 	}
-	verificationToken := generateToken()
-	user.VerificationToken = verificationToken
-	if err := s.repo.UpdateUser(context.Background(), user); err != nil {
-		return ErrDatabaseUpdateFailed
-	}
-	mailService := NewMailService()
-	baseURL := os.Getenv("APP_BASE_URL")
-	verificationLink := baseURL + "/auth/verify-email?token=" + verificationToken
-	emailBody := "Lütfen email adresinizi doğrulamak için aşağıdaki bağlantıya tıklayın: " + verificationLink
-	return mailService.SendMail(user.Email, "Email Doğrulama", emailBody)
+	return s.SendVerificationLink(user)
 }
 
-func generateToken() string {
-	tokenBytes := make([]byte, 16) // 16 byte = 128 bit
-	if _, err := rand.Read(tokenBytes); err != nil {
-		logconfig.Log.Error("Token oluşturulamadı", zap.Error(err))
-		return ""
+// SendMagicLink, aktif ve e-postası doğrulanmış kullanıcıya tek kullanımlık giriş bağlantısı gönderir.
+func (s *AuthService) SendMagicLink(email string) error {
+	user, err := s.getUserByEmail(email)
+	if err != nil {
+		return err
 	}
-	return hex.EncodeToString(tokenBytes)
+	if err := checkMagicLinkEligibility(user); err != nil {
+		s.logWarn("Giriş bağlantısı gönderimi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return err
+	}
+
+	ctx := context.Background()
+	loginToken, err := s.tokens.Issue(ctx, user.ID, models.TokenPurposeMagicLink, "")
+	if err != nil {
+		return ErrAuthGeneric
+	}
+
+	loginLink := os.Getenv("APP_BASE_URL") + "/auth/magic-link?token=" + loginToken
+	validFor := int(s.tokens.TTL(models.TokenPurposeMagicLink).Minutes())
+	emailBody := fmt.Sprintf("Hesabınıza giriş yapmak için aşağıdaki bağlantıya tıklayın: %s\n\nBağlantı %d dakika geçerlidir ve yalnızca bir kez kullanılabilir. Bu isteği siz yapmadıysanız bu e-postayı dikkate almayın.", loginLink, validFor)
+
+	if err := NewMailService().SendMail(user.Email, "Giriş Bağlantısı", emailBody); err != nil {
+		return fmt.Errorf("giriş bağlantısı e-postası gönderilemedi: %w", err)
+	}
+
+	logconfig.Log.Info("Giriş bağlantısı gönderildi", zap.Uint("user_id", user.ID))
+	return nil
 }
 
-func (s *AuthService) FindOrCreateUser(user models.User) (*models.User, error) {
-	existingUser, err := s.repo.FindByProviderAndID(user.Provider, user.ProviderID)
-	if err == nil {
-		return existingUser, nil
+// LoginWithMagicLink, bağlantıdaki tokenı tüketir ve giriş yapılabilecek kullanıcıyı döner.
+// Durum kontrolleri bağlantı gönderildikten sonra değişmiş olabileceği için burada tekrarlanır.
+func (s *AuthService) LoginWithMagicLink(token string) (*models.User, error) {
+	authToken, err := s.tokens.Consume(context.Background(), token, models.TokenPurposeMagicLink)
+	if err != nil {
+		if errors.Is(err, ErrTokenInvalid) {
+			return nil, ErrTokenInvalid
+		}
+		return nil, ErrAuthGeneric
 	}
 
-	if err := s.repo.CreateUser(context.Background(), &user); err != nil {
+	user, err := s.getUserByID(authToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMagicLinkEligibility(user); err != nil {
+		s.logWarn("Giriş bağlantısı ile giriş", zap.Uint("user_id", user.ID), zap.Error(err))
 		return nil, err
 	}
 
-	return &user, nil
+	s.logAuthSuccess(user.Email, user.ID)
+	return user, nil
+}
+
+func checkMagicLinkEligibility(user *models.User) error {
+	if !user.Status {
+		return ErrUserInactive
+	}
+	if !user.EmailVerified {
+		return ErrEmailNotVerified
+	}
+	return nil
 }
 
 var _ IAuthService = (*AuthService)(nil)
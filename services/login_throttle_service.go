@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/configs/databaseconfig"
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/attemptstore"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAccountLocked   = apperrors.TooManyRequests("account_locked", "Çok fazla başarısız giriş denemesi nedeniyle hesabınız geçici olarak kilitlendi. E-posta adresinize gönderilen bağlantı ile kilidi kaldırabilirsiniz.")
+	ErrUnlockFailed    = apperrors.Internal("unlock_failed", "Hesap kilidi kaldırılamadı.")
+	ErrTooManyAttempts = apperrors.TooManyRequests("too_many_attempts", "Çok fazla başarısız deneme yapıldı. Lütfen biraz bekleyip tekrar deneyin.")
+	ErrMagicLinkLimit  = apperrors.TooManyRequests("magic_link_rate_limited", "Çok fazla giriş bağlantısı istendi. Lütfen biraz bekleyip tekrar deneyin.")
+)
+
+const (
+	AttemptStoreMemory   = "memory"
+	AttemptStorePostgres = "postgres"
+
+	// İlk iki hatalı denemeden sonra bekleme süresi her denemede ikiye katlanır.
+	progressiveDelayFreeAttempts = 2
+	progressiveDelayMax          = 30 * time.Second
+)
+
+// loginAttemptStore tüm servis örnekleri arasında paylaşılır.
+var loginAttemptStore = sync.OnceValue(func() attemptstore.Store {
+	driver := envconfig.GetEnvWithDefault("AUTH_ATTEMPT_STORE", AttemptStoreMemory)
+	switch driver {
+	case AttemptStorePostgres:
+		return attemptstore.NewPostgresStore(databaseconfig.GetDB())
+	case AttemptStoreMemory:
+	default:
+		logconfig.SLog.Warnf("Bilinmeyen AUTH_ATTEMPT_STORE değeri '%s', bellek tabanlı depo kullanılacak.", driver)
+	}
+	return attemptstore.NewMemoryStore()
+})
+
+type ILoginThrottleService interface {
+	Check(ctx context.Context, email, ip string) error
+	RegisterFailure(ctx context.Context, email, ip string, user *models.User) error
+	RegisterSuccess(ctx context.Context, email string)
+	IsLocked(ctx context.Context, email string) bool
+	Unlock(ctx context.Context, email string) error
+	UnlockWithToken(ctx context.Context, token string) error
+	CheckTwoFactor(ctx context.Context, userID uint) error
+	RegisterTwoFactorFailure(ctx context.Context, userID uint) error
+	RegisterTwoFactorSuccess(ctx context.Context, userID uint)
+	RegisterMagicLinkRequest(ctx context.Context, email, ip string) error
+}
+
+type LoginThrottleService struct {
+	store          attemptstore.Store
+	repo           repositories.IAuthRepository
+	tokens         ITokenService
+	maxPerEmail    int
+	maxPerIP       int
+	maxTwoFactor   int
+	maxMagicEmail  int
+	maxMagicIP     int
+	window         time.Duration
+	lockoutTimeout time.Duration
+}
+
+func NewLoginThrottleService() ILoginThrottleService {
+	return &LoginThrottleService{
+		store:          loginAttemptStore(),
+		repo:           repositories.NewAuthRepository(),
+		tokens:         NewTokenService(),
+		maxPerEmail:    envconfig.GetEnvAsInt("AUTH_MAX_FAILED_ATTEMPTS", 5),
+		maxPerIP:       envconfig.GetEnvAsInt("AUTH_MAX_FAILED_ATTEMPTS_PER_IP", 20),
+		maxTwoFactor:   envconfig.GetEnvAsInt("AUTH_2FA_MAX_FAILED_ATTEMPTS", 5),
+		maxMagicEmail:  envconfig.GetEnvAsInt("AUTH_MAGIC_LINK_MAX_PER_EMAIL", 3),
+		maxMagicIP:     envconfig.GetEnvAsInt("AUTH_MAGIC_LINK_MAX_PER_IP", 10),
+		window:         time.Duration(envconfig.GetEnvAsInt("AUTH_ATTEMPT_WINDOW_MINUTES", 15)) * time.Minute,
+		lockoutTimeout: time.Duration(envconfig.GetEnvAsInt("AUTH_LOCKOUT_MINUTES", 15)) * time.Minute,
+	}
+}
+
+func emailAttemptKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+// twoFactorAttemptKey, ikinci adım hatalarını kullanıcıya bağlar; parolayla yeniden
+// giriş yapmak bu sayacı sıfırlamaz.
+func twoFactorAttemptKey(userID uint) string {
+	return fmt.Sprintf("2fa:%d", userID)
+}
+
+func magicLinkAttemptKey(key string) string {
+	return "magic:" + key
+}
+
+func progressiveDelay(failures int) time.Duration {
+	if failures <= progressiveDelayFreeAttempts {
+		return 0
+	}
+	delay := time.Second << min(failures-progressiveDelayFreeAttempts-1, 5)
+	return min(delay, progressiveDelayMax)
+}
+
+// Check, giriş denemesine izin verilip verilmediğini kontrol eder. Depoya
+// ulaşılamazsa giriş engellenmez, hata loglanır.
+func (s *LoginThrottleService) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+
+	emailEntry, err := s.store.Get(ctx, emailAttemptKey(email))
+	if err != nil {
+		logconfig.Log.Error("Giriş deneme sayacı okunamadı", zap.Error(err))
+	} else {
+		if emailEntry.Locked(now) {
+			return ErrAccountLocked
+		}
+		if now.Before(emailEntry.LastAttemptAt.Add(progressiveDelay(emailEntry.Count))) {
+			return ErrTooManyAttempts
+		}
+	}
+
+	if ip == "" {
+		return nil
+	}
+	ipEntry, err := s.store.Get(ctx, ipAttemptKey(ip))
+	if err != nil {
+		logconfig.Log.Error("Giriş deneme sayacı okunamadı", zap.Error(err))
+		return nil
+	}
+	if ipEntry.Locked(now) || now.Before(ipEntry.LastAttemptAt.Add(progressiveDelay(ipEntry.Count))) {
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+// RegisterFailure başarısız denemeyi kaydeder. Deneme hesabı kilitlediyse
+// ErrAccountLocked döner ve kullanıcıya kilit açma bağlantısı gönderilir.
+func (s *LoginThrottleService) RegisterFailure(ctx context.Context, email, ip string, user *models.User) error {
+	until := time.Now().Add(s.lockoutTimeout)
+
+	if ip != "" {
+		ipEntry, err := s.store.Increment(ctx, ipAttemptKey(ip), s.window)
+		if err != nil {
+			logconfig.Log.Error("Giriş deneme sayacı güncellenemedi", zap.String("ip", ip), zap.Error(err))
+		} else if ipEntry.Count >= s.maxPerIP {
+			if err := s.store.Lock(ctx, ipAttemptKey(ip), until); err != nil {
+				logconfig.Log.Error("IP adresi kilitlenemedi", zap.String("ip", ip), zap.Error(err))
+			}
+			logconfig.Log.Warn("IP adresi çok fazla başarısız giriş nedeniyle engellendi", zap.String("ip", ip), zap.Int("count", ipEntry.Count))
+		}
+	}
+
+	emailEntry, err := s.store.Increment(ctx, emailAttemptKey(email), s.window)
+	if err != nil {
+		logconfig.Log.Error("Giriş deneme sayacı güncellenemedi", zap.String("email", email), zap.Error(err))
+		return nil
+	}
+	if emailEntry.Count < s.maxPerEmail {
+		return nil
+	}
+
+	if err := s.store.Lock(ctx, emailAttemptKey(email), until); err != nil {
+		logconfig.Log.Error("Hesap kilitlenemedi", zap.String("email", email), zap.Error(err))
+		return nil
+	}
+	logconfig.Log.Warn("Hesap çok fazla başarısız giriş nedeniyle kilitlendi",
+		zap.String("email", email),
+		zap.String("ip", ip),
+		zap.Int("count", emailEntry.Count),
+	)
+	if user != nil {
+		if err := s.sendLockNotification(ctx, user, until); err != nil {
+			logconfig.Log.Error("Hesap kilidi bildirimi gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+	}
+	return ErrAccountLocked
+}
+
+func (s *LoginThrottleService) RegisterSuccess(ctx context.Context, email string) {
+	if err := s.store.Reset(ctx, emailAttemptKey(email)); err != nil {
+		logconfig.Log.Warn("Giriş deneme sayacı sıfırlanamadı", zap.String("email", email), zap.Error(err))
+	}
+}
+
+func (s *LoginThrottleService) IsLocked(ctx context.Context, email string) bool {
+	entry, err := s.store.Get(ctx, emailAttemptKey(email))
+	if err != nil {
+		logconfig.Log.Error("Giriş deneme sayacı okunamadı", zap.Error(err))
+		return false
+	}
+	return entry.Locked(time.Now())
+}
+
+func (s *LoginThrottleService) Unlock(ctx context.Context, email string) error {
+	if err := s.store.Reset(ctx, emailAttemptKey(email)); err != nil {
+		logconfig.Log.Error("Hesap kilidi kaldırılamadı", zap.String("email", email), zap.Error(err))
+		return ErrUnlockFailed.Wrap(err)
+	}
+	logconfig.Log.Info("Hesap kilidi kaldırıldı", zap.String("email", email))
+	return nil
+}
+
+func (s *LoginThrottleService) UnlockWithToken(ctx context.Context, token string) error {
+	authToken, err := s.tokens.Consume(ctx, token, models.TokenPurposeAccountUnlock)
+	if err != nil {
+		if errors.Is(err, ErrTokenInvalid) {
+			return ErrTokenInvalid
+		}
+		return ErrAuthGeneric
+	}
+
+	user, err := s.repo.FindUserByID(authToken.UserID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	return s.Unlock(ctx, user.Email)
+}
+
+// CheckTwoFactor, kullanıcının ikinci adım doğrulaması kilitliyse ErrTooManyAttempts döner.
+func (s *LoginThrottleService) CheckTwoFactor(ctx context.Context, userID uint) error {
+	entry, err := s.store.Get(ctx, twoFactorAttemptKey(userID))
+	if err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama deneme sayacı okunamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil
+	}
+	if entry.Locked(time.Now()) {
+		return ErrTooManyAttempts
+	}
+	return nil
+}
+
+// RegisterTwoFactorFailure, hatalı TOTP veya kurtarma kodu denemesini kaydeder.
+// Sınır aşıldığında ikinci adım kilitlenir ve ErrTooManyAttempts döner.
+func (s *LoginThrottleService) RegisterTwoFactorFailure(ctx context.Context, userID uint) error {
+	key := twoFactorAttemptKey(userID)
+	entry, err := s.store.Increment(ctx, key, s.window)
+	if err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama deneme sayacı güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil
+	}
+	if entry.Count < s.maxTwoFactor {
+		return nil
+	}
+
+	if err := s.store.Lock(ctx, key, time.Now().Add(s.lockoutTimeout)); err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama kilitlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil
+	}
+	logconfig.Log.Warn("İki adımlı doğrulama çok fazla hatalı deneme nedeniyle kilitlendi",
+		zap.Uint("user_id", userID),
+		zap.Int("count", entry.Count),
+	)
+	return ErrTooManyAttempts
+}
+
+func (s *LoginThrottleService) RegisterTwoFactorSuccess(ctx context.Context, userID uint) {
+	if err := s.store.Reset(ctx, twoFactorAttemptKey(userID)); err != nil {
+		logconfig.Log.Warn("İki adımlı doğrulama deneme sayacı sıfırlanamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+// RegisterMagicLinkRequest, giriş bağlantısı isteğini e-posta adresi ve IP için sayar;
+// pencere içinde sınır aşılırsa ErrMagicLinkLimit döner ve e-posta gönderilmemelidir.
+// Sayaç hesabın var olup olmamasından bağımsız tutulur.
+func (s *LoginThrottleService) RegisterMagicLinkRequest(ctx context.Context, email, ip string) error {
+	limited := s.countRequest(ctx, magicLinkAttemptKey(emailAttemptKey(email)), s.maxMagicEmail)
+	if ip != "" && s.countRequest(ctx, magicLinkAttemptKey(ipAttemptKey(ip)), s.maxMagicIP) {
+		limited = true
+	}
+	if limited {
+		logconfig.Log.Warn("Giriş bağlantısı istek sınırı aşıldı", zap.String("email", email), zap.String("ip", ip))
+		return ErrMagicLinkLimit
+	}
+	return nil
+}
+
+// countRequest, anahtarın sayacını artırır ve pencere içinde max aşıldıysa true döner.
+// Depoya ulaşılamazsa istek engellenmez.
+func (s *LoginThrottleService) countRequest(ctx context.Context, key string, max int) bool {
+	entry, err := s.store.Increment(ctx, key, s.window)
+	if err != nil {
+		logconfig.Log.Error("İstek sayacı güncellenemedi", zap.String("key", key), zap.Error(err))
+		return false
+	}
+	return entry.Count > max
+}
+
+func (s *LoginThrottleService) sendLockNotification(ctx context.Context, user *models.User, until time.Time) error {
+	unlockToken, err := s.tokens.Issue(ctx, user.ID, models.TokenPurposeAccountUnlock, "")
+	if err != nil {
+		return err
+	}
+
+	unlockLink := os.Getenv("APP_BASE_URL") + "/auth/unlock?token=" + unlockToken
+	emailBody := fmt.Sprintf(
+		"Hesabınızda çok sayıda başarısız giriş denemesi tespit edildi ve hesabınız %s saatine kadar kilitlendi. "+
+			"Bu denemeler size aitse aşağıdaki bağlantıya tıklayarak kilidi hemen kaldırabilirsiniz: %s\n\n"+
+			"Bu denemeleri siz yapmadıysanız şifrenizi değiştirmenizi öneririz.",
+		until.Format("15:04"), unlockLink,
+	)
+	return NewMailService().SendMail(user.Email, "Hesabınız Geçici Olarak Kilitlendi", emailBody)
+}
+
+var _ ILoginThrottleService = (*LoginThrottleService)(nil)
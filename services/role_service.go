@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/queryparams"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRoleNotFound          = apperrors.NotFound("role_not_found", "Rol bulunamadı.")
+	ErrRoleNameRequired      = apperrors.Unprocessable("role_name_required", "Rol adı boş olamaz.")
+	ErrAdminRoleProtected    = apperrors.Forbidden("admin_role_protected", "Admin rolü silinemez.")
+	ErrRoleListFailed        = apperrors.Internal("role_list_failed", "Roller getirilirken bir hata oluştu.")
+	ErrRoleCreateFailed      = apperrors.Internal("role_create_failed", "Rol oluşturulurken bir hata oluştu.")
+	ErrRoleUpdateFailed      = apperrors.Internal("role_update_failed", "Rol güncellenirken bir hata oluştu.")
+	ErrRoleDeleteFailed      = apperrors.Internal("role_delete_failed", "Rol silinirken bir hata oluştu.")
+	ErrRolePermissionsFailed = apperrors.Internal("role_permissions_failed", "Rol yetkileri kaydedilirken bir hata oluştu.")
+	ErrPermissionListFailed  = apperrors.Internal("permission_list_failed", "Yetkiler getirilirken bir hata oluştu.")
+)
+
+type IRoleService interface {
+	GetAllRoles(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetRoleList() ([]models.Role, error)
+	GetRoleByID(id uint) (*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role, permissionIDs []uint) error
+	UpdateRole(ctx context.Context, id uint, roleData *models.Role, permissionIDs []uint) error
+	DeleteRole(ctx context.Context, id uint) error
+	GetAllPermissions() ([]models.Permission, error)
+}
+
+type RoleService struct {
+	repo repositories.IRoleRepository
+}
+
+func NewRoleService() IRoleService {
+	return &RoleService{repo: repositories.NewRoleRepository()}
+}
+
+func (s *RoleService) GetAllRoles(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	roles, totalCount, err := s.repo.GetAllRoles(params)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidFilter) {
+			return nil, ErrInvalidFilter.Wrap(err)
+		}
+		logconfig.Log.Error("Roller alınamadı", zap.Error(err))
+		return nil, ErrRoleListFailed.Wrap(err)
+	}
+
+	result := &queryparams.PaginatedResult{
+		Data: roles,
+		Meta: queryparams.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  totalCount,
+			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}
+	return result, nil
+}
+
+func (s *RoleService) GetRoleList() ([]models.Role, error) {
+	roles, err := s.repo.GetRoleList()
+	if err != nil {
+		logconfig.Log.Error("Rol listesi alınamadı", zap.Error(err))
+		return nil, ErrRoleListFailed.Wrap(err)
+	}
+	return roles, nil
+}
+
+func (s *RoleService) GetRoleByID(id uint) (*models.Role, error) {
+	role, err := s.repo.GetRoleByID(id)
+	if err != nil {
+		logconfig.Log.Warn("Rol bulunamadı", zap.Uint("role_id", id), zap.Error(err))
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (s *RoleService) CreateRole(ctx context.Context, role *models.Role, permissionIDs []uint) error {
+	if role.Name == "" {
+		return ErrRoleNameRequired
+	}
+	if err := s.repo.CreateRole(ctx, role); err != nil {
+		logconfig.Log.Error("Rol oluşturulamadı", zap.String("name", role.Name), zap.Error(err))
+		return ErrRoleCreateFailed.Wrap(err)
+	}
+	if err := s.repo.ReplaceRolePermissions(ctx, role.ID, permissionIDs); err != nil {
+		logconfig.Log.Error("Rol yetkileri kaydedilemedi", zap.Uint("role_id", role.ID), zap.Error(err))
+		return ErrRolePermissionsFailed.Wrap(err)
+	}
+	return nil
+}
+
+func (s *RoleService) UpdateRole(ctx context.Context, id uint, roleData *models.Role, permissionIDs []uint) error {
+	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
+	if !ok || currentUserID == 0 {
+		return ErrMissingActor
+	}
+
+	if _, err := s.repo.GetRoleByID(id); err != nil {
+		return ErrRoleNotFound
+	}
+
+	updateData := map[string]interface{}{
+		"name":        roleData.Name,
+		"description": roleData.Description,
+	}
+	if err := s.repo.UpdateRole(ctx, id, updateData, currentUserID); err != nil {
+		logconfig.Log.Error("Rol güncellenemedi", zap.Uint("role_id", id), zap.Error(err))
+		return ErrRoleUpdateFailed.Wrap(err)
+	}
+	if err := s.repo.ReplaceRolePermissions(ctx, id, permissionIDs); err != nil {
+		logconfig.Log.Error("Rol yetkileri kaydedilemedi", zap.Uint("role_id", id), zap.Error(err))
+		return ErrRolePermissionsFailed.Wrap(err)
+	}
+	// Rolün yetkileri değiştiğinde hangi kullanıcıları etkilediği önbellekte
+	// bilinmediği için tüm kullanıcı önbelleği temizlenir.
+	ResetUserCache()
+	return nil
+}
+
+func (s *RoleService) DeleteRole(ctx context.Context, id uint) error {
+	role, err := s.repo.GetRoleByID(id)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+	if role.Name == models.RoleAdmin {
+		return ErrAdminRoleProtected
+	}
+	if err := s.repo.DeleteRole(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrMissingUserID) {
+			return ErrMissingActor
+		}
+		logconfig.Log.Error("Rol silinemedi", zap.Uint("role_id", id), zap.Error(err))
+		return ErrRoleDeleteFailed.Wrap(err)
+	}
+	ResetUserCache()
+	return nil
+}
+
+func (s *RoleService) GetAllPermissions() ([]models.Permission, error) {
+	permissions, err := s.repo.GetAllPermissions()
+	if err != nil {
+		logconfig.Log.Error("Yetkiler alınamadı", zap.Error(err))
+		return nil, ErrPermissionListFailed.Wrap(err)
+	}
+	return permissions, nil
+}
+
+var _ IRoleService = (*RoleService)(nil)
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var ErrTokenInvalid = apperrors.BadRequest("token_invalid", "Bağlantı geçersiz veya süresi dolmuş.")
+
+const authTokenBytes = 32
+
+// tokenTTLs, her amaç için varsayılan geçerlilik süresini (dakika) ve
+// değiştirmek için kullanılacak ortam değişkenini tanımlar.
+var tokenTTLs = map[models.AuthTokenPurpose]struct {
+	envKey         string
+	defaultMinutes int
+}{
+	models.TokenPurposePasswordReset:     {"AUTH_TOKEN_TTL_PASSWORD_RESET_MINUTES", 60},
+	models.TokenPurposeEmailVerification: {"AUTH_TOKEN_TTL_EMAIL_VERIFICATION_MINUTES", 1440},
+	models.TokenPurposeMagicLink:         {"AUTH_TOKEN_TTL_MAGIC_LINK_MINUTES", 15},
+	models.TokenPurposeEmailChange:       {"AUTH_TOKEN_TTL_EMAIL_CHANGE_MINUTES", 60},
+	models.TokenPurposeAccountUnlock:     {"AUTH_TOKEN_TTL_ACCOUNT_UNLOCK_MINUTES", 60},
+	models.TokenPurposeIdentityLink:      {"AUTH_TOKEN_TTL_IDENTITY_LINK_MINUTES", 30},
+}
+
+type ITokenService interface {
+	Issue(ctx context.Context, userID uint, purpose models.AuthTokenPurpose, payload string) (string, error)
+	Consume(ctx context.Context, plainToken string, purpose models.AuthTokenPurpose) (*models.AuthToken, error)
+	TTL(purpose models.AuthTokenPurpose) time.Duration
+}
+
+type TokenService struct {
+	repo repositories.IAuthTokenRepository
+}
+
+func NewTokenService() ITokenService {
+	return &TokenService{repo: repositories.NewAuthTokenRepository()}
+}
+
+func (s *TokenService) TTL(purpose models.AuthTokenPurpose) time.Duration {
+	cfg, ok := tokenTTLs[purpose]
+	if !ok {
+		return 15 * time.Minute
+	}
+	return time.Duration(envconfig.GetEnvAsInt(cfg.envKey, cfg.defaultMinutes)) * time.Minute
+}
+
+// Issue yeni bir token üretir ve kullanıcının aynı amaçla verilmiş önceki tokenlarını geçersiz kılar.
+// Dönen düz token yalnızca e-posta bağlantısında kullanılmalıdır.
+func (s *TokenService) Issue(ctx context.Context, userID uint, purpose models.AuthTokenPurpose, payload string) (string, error) {
+	raw := make([]byte, authTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		logconfig.Log.Error("Token oluşturulamadı", zap.Error(err))
+		return "", err
+	}
+	plainToken := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := s.repo.DeleteForUser(ctx, userID, purpose); err != nil {
+		logconfig.Log.Error("Eski tokenlar silinemedi", zap.Uint("user_id", userID), zap.String("purpose", string(purpose)), zap.Error(err))
+		return "", err
+	}
+
+	token := &models.AuthToken{
+		UserID:    userID,
+		TokenHash: hashAuthToken(plainToken),
+		Purpose:   purpose,
+		Payload:   payload,
+		ExpiresAt: time.Now().UTC().Add(s.TTL(purpose)),
+	}
+	if err := s.repo.Create(ctx, token); err != nil {
+		logconfig.Log.Error("Token kaydedilemedi", zap.Uint("user_id", userID), zap.String("purpose", string(purpose)), zap.Error(err))
+		return "", err
+	}
+	return plainToken, nil
+}
+
+func (s *TokenService) Consume(ctx context.Context, plainToken string, purpose models.AuthTokenPurpose) (*models.AuthToken, error) {
+	if plainToken == "" {
+		return nil, ErrTokenInvalid
+	}
+
+	token, err := s.repo.Consume(ctx, hashAuthToken(plainToken), purpose)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logconfig.Log.Warn("Geçersiz veya kullanılmış token", zap.String("purpose", string(purpose)))
+			return nil, ErrTokenInvalid
+		}
+		logconfig.Log.Error("Token doğrulanamadı", zap.String("purpose", string(purpose)), zap.Error(err))
+		return nil, err
+	}
+	return token, nil
+}
+
+func hashAuthToken(plainToken string) string {
+	sum := sha256.Sum256([]byte(plainToken))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ ITokenService = (*TokenService)(nil)
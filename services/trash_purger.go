@@ -0,0 +1,86 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTrashRetentionDays        = 30
+	defaultTrashPurgeIntervalMinutes = 60
+)
+
+// TrashPurger, çöp kutusunda TRASH_RETENTION_DAYS günden uzun kalan kullanıcıları
+// TRASH_PURGE_INTERVAL_MINUTES aralıklarla kalıcı olarak siler. Saklama süresi 0 ya
+// da negatifse temizlik yapılmaz.
+type TrashPurger struct {
+	userService IUserService
+	retention   time.Duration
+	interval    time.Duration
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+func NewTrashPurger() *TrashPurger {
+	retentionDays := envconfig.GetEnvAsInt("TRASH_RETENTION_DAYS", defaultTrashRetentionDays)
+	intervalMinutes := envconfig.GetEnvAsInt("TRASH_PURGE_INTERVAL_MINUTES", defaultTrashPurgeIntervalMinutes)
+	if intervalMinutes <= 0 {
+		intervalMinutes = defaultTrashPurgeIntervalMinutes
+	}
+
+	return &TrashPurger{
+		userService: NewUserService(),
+		retention:   time.Duration(retentionDays) * 24 * time.Hour,
+		interval:    time.Duration(intervalMinutes) * time.Minute,
+		done:        make(chan struct{}),
+	}
+}
+
+func (p *TrashPurger) Start() {
+	if p.retention <= 0 {
+		logconfig.SLog.Info("TRASH_RETENTION_DAYS sıfır ya da negatif, çöp kutusu otomatik temizlenmeyecek.")
+		return
+	}
+	logconfig.Log.Info("Çöp kutusu temizleme görevi başlatıldı",
+		zap.Duration("retention", p.retention),
+		zap.Duration("interval", p.interval),
+	)
+	go p.loop()
+}
+
+func (p *TrashPurger) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+func (p *TrashPurger) loop() {
+	p.purge()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.purge()
+		}
+	}
+}
+
+func (p *TrashPurger) purge() {
+	count, err := p.userService.PurgeTrashedUsers(p.retention)
+	if err != nil {
+		return
+	}
+	if count > 0 {
+		logconfig.Log.Info("Çöp kutusundaki eski kullanıcılar kalıcı olarak silindi", zap.Int64("count", count))
+	}
+}
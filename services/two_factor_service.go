@@ -0,0 +1,273 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"image/png"
+	"strings"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/repositories"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTwoFactorInvalidCode    = apperrors.Unprocessable("two_factor_invalid_code", "Doğrulama kodu geçersiz.")
+	ErrTwoFactorNotEnabled     = apperrors.BadRequest("two_factor_not_enabled", "İki adımlı doğrulama etkin değil.")
+	ErrTwoFactorAlreadyEnabled = apperrors.Conflict("two_factor_already_enabled", "İki adımlı doğrulama zaten etkin.")
+	ErrTwoFactorEnforced       = apperrors.Forbidden("two_factor_enforced", "İki adımlı doğrulama hesabınız için zorunlu tutuluyor.")
+	ErrTwoFactorGeneric        = apperrors.Internal("two_factor_failed", "İki adımlı doğrulama işlemi sırasında bir hata oluştu.")
+)
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// 32 karakterlik alfabe, rastgele baytların eşit dağılmasını sağlar.
+	recoveryCodeAlphabet   = "0123456789abcdefghjkmnpqrstvwxyz"
+	twoFactorQRCodeSize    = 200
+	twoFactorDefaultIssuer = "zatrano"
+	totpPeriod             = 30
+	// totpSkew, saat farkına karşı bir önceki ve bir sonraki adımın da kabul edilmesini sağlar.
+	totpSkew = 1
+)
+
+// TwoFactorSetup, kurulum ekranında gösterilecek gizli anahtar ve QR kodunu taşır.
+type TwoFactorSetup struct {
+	Secret    string
+	QRCodeURI string
+}
+
+type ITwoFactorService interface {
+	GenerateSetup(user *models.User) (*TwoFactorSetup, error)
+	Enable(ctx context.Context, user *models.User, secret, code string) ([]string, error)
+	Verify(ctx context.Context, user *models.User, code string) error
+	Disable(ctx context.Context, user *models.User, password string) error
+	RegenerateRecoveryCodes(ctx context.Context, user *models.User, password string) ([]string, error)
+	RemainingRecoveryCodes(userID uint) (int64, error)
+}
+
+type TwoFactorService struct {
+	repo repositories.ITwoFactorRepository
+}
+
+func NewTwoFactorService() ITwoFactorService {
+	return &TwoFactorService{repo: repositories.NewTwoFactorRepository()}
+}
+
+func (s *TwoFactorService) GenerateSetup(user *models.User) (*TwoFactorSetup, error) {
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      envconfig.GetEnvWithDefault("AUTH_2FA_ISSUER", twoFactorDefaultIssuer),
+		AccountName: user.Email,
+	})
+	if err != nil {
+		logconfig.Log.Error("TOTP anahtarı oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+
+	qrCodeURI, err := qrCodeDataURI(key)
+	if err != nil {
+		logconfig.Log.Error("TOTP QR kodu oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+
+	return &TwoFactorSetup{Secret: key.Secret(), QRCodeURI: qrCodeURI}, nil
+}
+
+func (s *TwoFactorService) Enable(ctx context.Context, user *models.User, secret, code string) ([]string, error) {
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if secret == "" {
+		return nil, ErrTwoFactorInvalidCode
+	}
+	counter, ok := matchTOTPCounter(secret, normalizeTwoFactorCode(code), time.Now())
+	if !ok {
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		logconfig.Log.Error("Kurtarma kodları oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+
+	if err := s.repo.Enable(ctx, user.ID, secret, counter, hashes); err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama etkinleştirilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+	InvalidateUserCache(user.ID)
+
+	logconfig.Log.Info("İki adımlı doğrulama etkinleştirildi", zap.Uint("user_id", user.ID))
+	return codes, nil
+}
+
+// Verify, giriş sırasında girilen TOTP kodunu veya kullanılmamış bir kurtarma kodunu doğrular.
+// Daha önce kabul edilmiş bir TOTP adımına ait kod tekrar kullanılamaz.
+func (s *TwoFactorService) Verify(ctx context.Context, user *models.User, code string) error {
+	if !user.TwoFactorEnabled || user.TwoFactorSecret == "" {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = normalizeTwoFactorCode(code)
+	if len(code) == 6 {
+		if counter, ok := matchTOTPCounter(user.TwoFactorSecret, code, time.Now()); ok {
+			accepted, err := s.repo.ConsumeTOTPCounter(ctx, user.ID, counter)
+			if err != nil {
+				logconfig.Log.Error("TOTP adımı kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+				return ErrTwoFactorGeneric
+			}
+			if accepted {
+				return nil
+			}
+			logconfig.Log.Warn("Daha önce kullanılmış TOTP kodu reddedildi", zap.Uint("user_id", user.ID))
+			return ErrTwoFactorInvalidCode
+		}
+	}
+
+	if len(code) == recoveryCodeLength {
+		consumed, err := s.repo.ConsumeRecoveryCode(ctx, user.ID, hashRecoveryCode(code))
+		if err != nil {
+			logconfig.Log.Error("Kurtarma kodu doğrulanamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+			return ErrTwoFactorGeneric
+		}
+		if consumed {
+			logconfig.Log.Info("Kurtarma kodu kullanıldı", zap.Uint("user_id", user.ID))
+			return nil
+		}
+	}
+
+	logconfig.Log.Warn("Geçersiz iki adımlı doğrulama kodu", zap.Uint("user_id", user.ID))
+	return ErrTwoFactorInvalidCode
+}
+
+func (s *TwoFactorService) Disable(ctx context.Context, user *models.User, password string) error {
+	if !user.TwoFactorEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if user.TwoFactorRequired {
+		return ErrTwoFactorEnforced
+	}
+	if err := user.CheckPassword(password); err != nil {
+		return ErrCurrentPasswordIncorrect
+	}
+
+	if err := s.repo.Disable(ctx, user.ID); err != nil {
+		logconfig.Log.Error("İki adımlı doğrulama kapatılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrTwoFactorGeneric
+	}
+	InvalidateUserCache(user.ID)
+
+	logconfig.Log.Info("İki adımlı doğrulama kapatıldı", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, user *models.User, password string) ([]string, error) {
+	if !user.TwoFactorEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+	if err := user.CheckPassword(password); err != nil {
+		return nil, ErrCurrentPasswordIncorrect
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		logconfig.Log.Error("Kurtarma kodları oluşturulamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, user.ID, hashes); err != nil {
+		logconfig.Log.Error("Kurtarma kodları kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+	return codes, nil
+}
+
+func (s *TwoFactorService) RemainingRecoveryCodes(userID uint) (int64, error) {
+	return s.repo.CountUnusedRecoveryCodes(userID)
+}
+
+// matchTOTPCounter, kodun eşleştiği TOTP adımını döner. totp.Validate yalnızca
+// doğru/yanlış döndürdüğü için adımlar burada tek tek üretilip karşılaştırılır.
+func matchTOTPCounter(secret, code string, now time.Time) (int64, bool) {
+	if len(code) != 6 {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		counter := current + offset
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(counter*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+func qrCodeDataURI(key *otp.Key) (string, error) {
+	img, err := key.Image(twoFactorQRCodeSize, twoFactorQRCodeSize)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// generateRecoveryCodes, kullanıcıya gösterilecek kodları (xxxxx-xxxxx) ve
+// veritabanına yazılacak özetlerini döner.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	buf := make([]byte, recoveryCodeLength)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := make([]byte, recoveryCodeLength)
+		for j, b := range buf {
+			raw[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		code := string(raw)
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeTwoFactorCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+var _ ITwoFactorService = (*TwoFactorService)(nil)
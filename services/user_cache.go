@@ -0,0 +1,36 @@
+package services
+
+import (
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/models"
+	"zatrano/pkg/ttlcache"
+)
+
+// userCache, her istekte kimlik doğrulama için yapılan kullanıcı sorgularını
+// kısa süreliğine bellekte tutar. Süre AUTH_USER_CACHE_TTL_SECONDS ile
+// ayarlanır; 0 verilirse önbellek devre dışı kalır.
+var userCache = ttlcache.New[uint, models.User](
+	time.Duration(envconfig.GetEnvAsInt("AUTH_USER_CACHE_TTL_SECONDS", 30)) * time.Second,
+)
+
+func cachedUser(id uint) (*models.User, bool) {
+	user, ok := userCache.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return &user, true
+}
+
+func cacheUser(user *models.User) {
+	userCache.Set(user.ID, *user)
+}
+
+func InvalidateUserCache(id uint) {
+	userCache.Delete(id)
+}
+
+func ResetUserCache() {
+	userCache.Reset()
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/oauthprovider"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdentityLinkRequired   = apperrors.Conflict("identity_link_required", "Bu e-posta adresiyle kayıtlı bir hesap var, bağlantı onayı gerekiyor.")
+	ErrIdentityAlreadyLinked  = apperrors.Conflict("identity_already_linked", "Bu sağlayıcı hesabı başka bir kullanıcıya bağlı.")
+	ErrIdentityProviderLinked = apperrors.Conflict("identity_provider_linked", "Bu sağlayıcı için zaten bağlı bir hesabınız var.")
+	ErrIdentityNotFound       = apperrors.NotFound("identity_not_found", "Bağlı hesap bulunamadı.")
+	ErrIdentityLastLogin      = apperrors.Unprocessable("identity_last_login", "Tek giriş yönteminizi kaldıramazsınız. Önce bir parola belirleyin veya başka bir hesap bağlayın.")
+	ErrIdentityGeneric        = apperrors.Internal("identity_failed", "Hesap bağlantısı sırasında bir hata oluştu.")
+)
+
+// identityLinkPayloadSeparator, e-posta ile onaylanan bağlantıda sağlayıcı ve subject değerlerini ayırır.
+const identityLinkPayloadSeparator = "\x1f"
+
+type IUserIdentityService interface {
+	Resolve(ctx context.Context, identity *oauthprovider.Identity) (*models.User, error)
+	Link(ctx context.Context, userID uint, identity *oauthprovider.Identity) error
+	SendLinkConfirmation(ctx context.Context, user *models.User, identity *oauthprovider.Identity) error
+	ConfirmLinkWithToken(ctx context.Context, token string) (*models.UserIdentity, error)
+	GetIdentities(userID uint) ([]models.UserIdentity, error)
+	Unlink(ctx context.Context, user *models.User, id uint) (*models.UserIdentity, error)
+}
+
+type UserIdentityService struct {
+	repo     repositories.IUserIdentityRepository
+	authRepo repositories.IAuthRepository
+	tokens   ITokenService
+}
+
+func NewUserIdentityService() IUserIdentityService {
+	return &UserIdentityService{
+		repo:     repositories.NewUserIdentityRepository(),
+		authRepo: repositories.NewAuthRepository(),
+		tokens:   NewTokenService(),
+	}
+}
+
+// Resolve, sağlayıcı kimliğine bağlı kullanıcıyı döner. Kimlik ilk kez görülüyorsa ve
+// e-posta adresi mevcut bir hesaba aitse, hesabı ele geçirmeyi önlemek için kullanıcı
+// ErrIdentityLinkRequired ile birlikte döner; bağlantı ancak onaydan sonra kurulur.
+func (s *UserIdentityService) Resolve(ctx context.Context, identity *oauthprovider.Identity) (*models.User, error) {
+	existing, err := s.repo.FindByProviderSubject(identity.Provider, identity.Subject)
+	if err == nil {
+		if err := s.repo.Touch(ctx, existing.ID); err != nil {
+			logconfig.Log.Warn("Bağlı hesap kullanım zamanı güncellenemedi", zap.Uint("identity_id", existing.ID), zap.Error(err))
+		}
+		user, err := s.authRepo.FindUserByID(existing.UserID)
+		if err != nil {
+			return nil, ErrIdentityGeneric
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logconfig.Log.Error("Bağlı hesap sorgulanamadı", zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, ErrIdentityGeneric
+	}
+
+	user, err := s.repo.FindUserByEmailFold(identity.Email)
+	if err == nil {
+		logconfig.Log.Info("Sağlayıcı kimliği mevcut hesapla eşleşti, onay bekleniyor",
+			zap.String("provider", identity.Provider),
+			zap.Uint("user_id", user.ID))
+		return user, ErrIdentityLinkRequired
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		logconfig.Log.Error("Kullanıcı sorgulanamadı", zap.String("email", identity.Email), zap.Error(err))
+		return nil, ErrIdentityGeneric
+	}
+
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = identity.Email
+	}
+	user = &models.User{
+		Name:          name,
+		Email:         identity.Email,
+		Status:        true,
+		Type:          models.Panel,
+		EmailVerified: identity.EmailVerified,
+	}
+	if err := s.repo.CreateUserWithIdentity(ctx, user, newUserIdentity(identity)); err != nil {
+		logconfig.Log.Error("Sağlayıcı ile kullanıcı oluşturulamadı", zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, ErrIdentityGeneric
+	}
+	logconfig.Log.Info("Sağlayıcı ile yeni kullanıcı oluşturuldu", zap.String("provider", identity.Provider), zap.Uint("user_id", user.ID))
+	return user, nil
+}
+
+func (s *UserIdentityService) Link(ctx context.Context, userID uint, identity *oauthprovider.Identity) error {
+	existing, err := s.repo.FindByProviderSubject(identity.Provider, identity.Subject)
+	if err == nil {
+		if existing.UserID == userID {
+			return nil
+		}
+		return ErrIdentityAlreadyLinked
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrIdentityGeneric
+	}
+
+	identities, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return ErrIdentityGeneric
+	}
+	for _, linked := range identities {
+		if linked.Provider == identity.Provider {
+			return ErrIdentityProviderLinked
+		}
+	}
+
+	record := newUserIdentity(identity)
+	record.UserID = userID
+	if err := s.repo.Create(ctx, record); err != nil {
+		logconfig.Log.Error("Hesap bağlanamadı", zap.Uint("user_id", userID), zap.String("provider", identity.Provider), zap.Error(err))
+		return ErrIdentityGeneric
+	}
+	logconfig.Log.Info("Sağlayıcı hesabı bağlandı", zap.Uint("user_id", userID), zap.String("provider", identity.Provider))
+	return nil
+}
+
+func (s *UserIdentityService) SendLinkConfirmation(ctx context.Context, user *models.User, identity *oauthprovider.Identity) error {
+	payload := identity.Provider + identityLinkPayloadSeparator + identity.Subject
+	if len(payload) > 255 {
+		return ErrIdentityGeneric
+	}
+
+	token, err := s.tokens.Issue(ctx, user.ID, models.TokenPurposeIdentityLink, payload)
+	if err != nil {
+		return ErrIdentityGeneric
+	}
+
+	link := os.Getenv("APP_BASE_URL") + "/auth/link/confirm?token=" + token
+	body := "Hesabınıza yeni bir giriş yöntemi bağlanması istendi. Bu isteği siz yaptıysanız onaylamak için aşağıdaki bağlantıya tıklayın: " + link +
+		"\n\nBu isteği siz yapmadıysanız bu e-postayı dikkate almayın."
+	if err := NewMailService().SendMail(user.Email, "Hesap Bağlantısı Onayı", body); err != nil {
+		logconfig.Log.Error("Hesap bağlantısı e-postası gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrIdentityGeneric
+	}
+	return nil
+}
+
+func (s *UserIdentityService) ConfirmLinkWithToken(ctx context.Context, token string) (*models.UserIdentity, error) {
+	authToken, err := s.tokens.Consume(ctx, token, models.TokenPurposeIdentityLink)
+	if err != nil {
+		if errors.Is(err, ErrTokenInvalid) {
+			return nil, ErrTokenInvalid
+		}
+		return nil, ErrIdentityGeneric
+	}
+
+	provider, subject, ok := strings.Cut(authToken.Payload, identityLinkPayloadSeparator)
+	if !ok || provider == "" || subject == "" {
+		return nil, ErrTokenInvalid
+	}
+
+	identity := &oauthprovider.Identity{Provider: provider, Subject: subject}
+	if err := s.Link(ctx, authToken.UserID, identity); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByProviderSubject(provider, subject)
+}
+
+func (s *UserIdentityService) GetIdentities(userID uint) ([]models.UserIdentity, error) {
+	identities, err := s.repo.GetByUser(userID)
+	if err != nil {
+		logconfig.Log.Error("Bağlı hesaplar alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrIdentityGeneric
+	}
+	return identities, nil
+}
+
+// Unlink, parolası olmayan kullanıcının son bağlı hesabını kaldırmasına izin vermez.
+func (s *UserIdentityService) Unlink(ctx context.Context, user *models.User, id uint) (*models.UserIdentity, error) {
+	identities, err := s.GetIdentities(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *models.UserIdentity
+	for i := range identities {
+		if identities[i].ID == id {
+			target = &identities[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, ErrIdentityNotFound
+	}
+	if user.Password == "" && len(identities) <= 1 {
+		return nil, ErrIdentityLastLogin
+	}
+
+	deleted, err := s.repo.DeleteForUser(ctx, user.ID, id)
+	if err != nil {
+		logconfig.Log.Error("Bağlı hesap kaldırılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrIdentityGeneric
+	}
+	if deleted == 0 {
+		return nil, ErrIdentityNotFound
+	}
+	logconfig.Log.Info("Sağlayıcı hesabı bağlantısı kaldırıldı", zap.Uint("user_id", user.ID), zap.String("provider", target.Provider))
+	return target, nil
+}
+
+func newUserIdentity(identity *oauthprovider.Identity) *models.UserIdentity {
+	now := time.Now().UTC()
+	email := identity.Email
+	if len(email) > 100 {
+		email = ""
+	}
+	return &models.UserIdentity{
+		Provider:   identity.Provider,
+		Subject:    identity.Subject,
+		Email:      email,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+}
+
+var _ IUserIdentityService = (*UserIdentityService)(nil)
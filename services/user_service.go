@@ -3,9 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"os"
+	"strings"
+	"time"
 
 	"zatrano/configs/logconfig"
 	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/auth"
 	"zatrano/pkg/queryparams"
 	"zatrano/repositories"
 
@@ -14,13 +19,42 @@ import (
 
 const contextUserIDKey = "user_id"
 
+var (
+	ErrInvalidUserType = apperrors.Unprocessable("invalid_user_type", "Geçersiz kullanıcı tipi.").
+				WithFields(map[string]string{"type": "Kullanıcı tipi dashboard veya panel olmalıdır."})
+	ErrInvalidFilter          = apperrors.BadRequest("invalid_filter", "Geçersiz filtre parametresi.")
+	ErrInvalidCursor          = apperrors.BadRequest("invalid_cursor", "Geçersiz sayfa imleci.")
+	ErrMissingActor           = apperrors.Unauthorized("actor_missing", "İşlemi yapan kullanıcı kimliği geçersiz.")
+	ErrUserListFailed         = apperrors.Internal("user_list_failed", "Kullanıcılar getirilirken bir hata oluştu.")
+	ErrUserCreateFailed       = apperrors.Internal("user_create_failed", "Kullanıcı oluşturulurken bir hata oluştu.")
+	ErrUserUpdateFailed       = apperrors.Internal("user_update_failed", "Kullanıcı güncellenirken bir hata oluştu.")
+	ErrUserDeleteFailed       = apperrors.Internal("user_delete_failed", "Kullanıcı silinirken bir hata oluştu.")
+	ErrUserRestoreFailed      = apperrors.Internal("user_restore_failed", "Kullanıcı geri getirilirken bir hata oluştu.")
+	ErrUserForceDeleteFailed  = apperrors.Internal("user_force_delete_failed", "Kullanıcı kalıcı olarak silinirken bir hata oluştu.")
+	ErrCannotDeleteSelf       = apperrors.Forbidden("cannot_delete_self", "Kendi hesabınızı silemezsiniz.")
+	ErrSystemUserProtected    = apperrors.Forbidden("system_user_protected", "Sistem kullanıcısı silinemez.")
+	ErrSystemUserReadOnly     = apperrors.Forbidden("system_user_read_only", "Sistem kullanıcısı kullanıcı yönetiminden değiştirilemez.")
+	ErrUserOutranksActor      = apperrors.Forbidden("user_outranks_actor", "Sahip olmadığınız yetkilere sahip bir kullanıcıyı değiştiremezsiniz.")
+	ErrCredentialChangeDenied = apperrors.Forbidden("credential_change_forbidden", "E-posta, şifre, kullanıcı tipi ve iki adımlı doğrulama ayarlarını değiştirmek için users.manage_credentials yetkisi gerekir.")
+	ErrUserPurgeFailed        = apperrors.Internal("user_purge_failed", "Çöp kutusu temizlenirken bir hata oluştu.")
+	ErrUserRolesFailed        = apperrors.Internal("user_roles_failed", "Kullanıcı rolleri kaydedilirken bir hata oluştu.")
+	ErrRequireTwoFactorFailed = apperrors.Internal("require_two_factor_failed", "İki adımlı doğrulama zorunluluğu kaydedilemedi.")
+)
+
 type IUserService interface {
 	GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	GetUsersByCursor(params queryparams.ListParams) (*queryparams.CursorResult, error)
 	GetUserByID(id uint) (*models.User, error)
 	CreateUser(ctx context.Context, user *models.User) error
 	UpdateUser(ctx context.Context, id uint, userData *models.User) error
 	DeleteUser(ctx context.Context, id uint) error
 	GetUserCount() (int64, error)
+	GetTrashedUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error)
+	RestoreUser(ctx context.Context, id uint) error
+	ForceDeleteUser(ctx context.Context, id uint) error
+	PurgeTrashedUsers(age time.Duration) (int64, error)
+	SyncUserRoles(ctx context.Context, userID uint, roleIDs []uint) error
+	RequireTwoFactorForDashboardUsers(ctx context.Context) error
 }
 
 type UserService struct {
@@ -34,11 +68,44 @@ func NewUserService() IUserService {
 func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
 	users, totalCount, err := s.repo.GetAllUsers(params)
 	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidFilter) {
+			return nil, ErrInvalidFilter.Wrap(err)
+		}
+		logconfig.Log.Error("Kullanıcılar alınamadı", zap.Error(err))
+		return nil, ErrUserListFailed.Wrap(err)
+	}
+	return paginateUsers(users, totalCount, params), nil
+}
+
+func (s *UserService) GetUsersByCursor(params queryparams.ListParams) (*queryparams.CursorResult, error) {
+	users, meta, err := s.repo.GetUsersByCursor(params)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidFilter) {
+			return nil, ErrInvalidFilter.Wrap(err)
+		}
+		if errors.Is(err, queryparams.ErrInvalidCursor) {
+			return nil, ErrInvalidCursor.Wrap(err)
+		}
 		logconfig.Log.Error("Kullanıcılar alınamadı", zap.Error(err))
-		return nil, errors.New("kullanıcılar getirilirken bir hata oluştu")
+		return nil, ErrUserListFailed.Wrap(err)
 	}
+	return &queryparams.CursorResult{Data: users, Meta: meta}, nil
+}
+
+func (s *UserService) GetTrashedUsers(params queryparams.ListParams) (*queryparams.PaginatedResult, error) {
+	users, totalCount, err := s.repo.GetTrashedUsers(params)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidFilter) {
+			return nil, ErrInvalidFilter.Wrap(err)
+		}
+		logconfig.Log.Error("Silinmiş kullanıcılar alınamadı", zap.Error(err))
+		return nil, ErrUserListFailed.Wrap(err)
+	}
+	return paginateUsers(users, totalCount, params), nil
+}
 
-	result := &queryparams.PaginatedResult{
+func paginateUsers(users []models.User, totalCount int64, params queryparams.ListParams) *queryparams.PaginatedResult {
+	return &queryparams.PaginatedResult{
 		Data: users,
 		Meta: queryparams.PaginationMeta{
 			CurrentPage: params.Page,
@@ -47,64 +114,246 @@ func (s *UserService) GetAllUsers(params queryparams.ListParams) (*queryparams.P
 			TotalPages:  queryparams.CalculateTotalPages(totalCount, params.PerPage),
 		},
 	}
-	return result, nil
 }
 
 func (s *UserService) GetUserByID(id uint) (*models.User, error) {
 	user, err := s.repo.GetUserByID(id)
 	if err != nil {
 		logconfig.Log.Warn("Kullanıcı bulunamadı", zap.Uint("user_id", id), zap.Error(err))
-		return nil, errors.New("kullanıcı bulunamadı")
+		return nil, ErrUserNotFound
 	}
 	return user, nil
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user *models.User) error {
+	if !validUserType(user.Type) {
+		return ErrInvalidUserType
+	}
 	if user.Password == "" {
-		return errors.New("şifre alanı boş olamaz")
+		return ErrPasswordRequired
 	}
 	if err := user.SetPassword(user.Password); err != nil {
 		logconfig.Log.Error("Şifre oluşturulamadı", zap.Error(err))
-		return errors.New("şifre oluşturulurken hata oluştu")
+		return ErrHashingFailed.Wrap(err)
+	}
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		logconfig.Log.Error("Kullanıcı oluşturulamadı", zap.String("email", user.Email), zap.Error(err))
+		return ErrUserCreateFailed.Wrap(err)
 	}
-	return s.repo.CreateUser(ctx, user)
+	return nil
 }
 
+// UpdateUser, users.update yetkisiyle ad ve durum gibi alanları değiştirir. Hesabın
+// ele geçirilmesine yol açabilecek alanlar (e-posta, şifre, tip, iki adımlı doğrulama)
+// ayrıca users.manage_credentials ister. İşlemi yapandan daha yetkili kullanıcılar ve
+// sistem kullanıcısı değiştirilemez.
 func (s *UserService) UpdateUser(ctx context.Context, id uint, userData *models.User) error {
 	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
 	if !ok || currentUserID == 0 {
-		return errors.New("güncelleyen kullanıcı kimliği geçersiz")
+		return ErrMissingActor
+	}
+
+	if !validUserType(userData.Type) {
+		return ErrInvalidUserType
 	}
 
-	_, err := s.repo.GetUserByID(id)
+	target, err := s.manageableUser(ctx, id)
 	if err != nil {
-		return errors.New("kullanıcı bulunamadı")
+		return err
+	}
+
+	credentialsChanged := userData.Password != "" ||
+		!strings.EqualFold(userData.Email, target.Email) ||
+		userData.Type != target.Type ||
+		userData.TwoFactorRequired != target.TwoFactorRequired
+	if credentialsChanged && !auth.CanContext(ctx, models.PermissionUsersManageCredentials) {
+		return ErrCredentialChangeDenied
 	}
 
 	updateData := map[string]interface{}{
-		"name":   userData.Name,
-		"email":  userData.Email,
-		"status": userData.Status,
-		"type":   userData.Type,
+		"name":                userData.Name,
+		"email":               userData.Email,
+		"status":              userData.Status,
+		"type":                userData.Type,
+		"two_factor_required": userData.TwoFactorRequired,
 	}
 
 	if userData.Password != "" {
 		hashed := models.User{}
 		if err := hashed.SetPassword(userData.Password); err != nil {
-			return errors.New("şifre oluşturulurken hata oluştu")
+			return ErrHashingFailed.Wrap(err)
 		}
 		updateData["password"] = hashed.Password
 	}
 
-	return s.repo.UpdateUser(ctx, id, updateData, currentUserID)
+	if err := s.repo.UpdateUser(ctx, id, updateData, currentUserID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		logconfig.Log.Error("Kullanıcı güncellenemedi", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserUpdateFailed.Wrap(err)
+	}
+	InvalidateUserCache(id)
+	return nil
 }
 
+// DeleteUser, kullanıcıyı çöp kutusuna taşır. İşlemi yapan kullanıcı kendini ve sistem
+// kullanıcısını silemez.
 func (s *UserService) DeleteUser(ctx context.Context, id uint) error {
-	return s.repo.DeleteUser(ctx, id)
+	user, err := s.repo.GetUserByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		logconfig.Log.Error("Kullanıcı alınamadı", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserDeleteFailed.Wrap(err)
+	}
+	if err := checkDeletable(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteUser(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		if errors.Is(err, repositories.ErrMissingUserID) {
+			return ErrMissingActor
+		}
+		logconfig.Log.Error("Kullanıcı silinemedi", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserDeleteFailed.Wrap(err)
+	}
+	InvalidateUserCache(id)
+	return nil
+}
+
+func (s *UserService) RestoreUser(ctx context.Context, id uint) error {
+	if err := s.repo.RestoreUser(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		logconfig.Log.Error("Kullanıcı geri getirilemedi", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserRestoreFailed.Wrap(err)
+	}
+	InvalidateUserCache(id)
+	return nil
+}
+
+// ForceDeleteUser yalnızca çöp kutusundaki kullanıcıları kalıcı olarak siler; DeleteUser
+// ile aynı koruma kuralları geçerlidir.
+func (s *UserService) ForceDeleteUser(ctx context.Context, id uint) error {
+	user, err := s.repo.GetTrashedUserByID(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		logconfig.Log.Error("Silinmiş kullanıcı alınamadı", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserForceDeleteFailed.Wrap(err)
+	}
+	if err := checkDeletable(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.repo.ForceDeleteUser(ctx, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		logconfig.Log.Error("Kullanıcı kalıcı olarak silinemedi", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserForceDeleteFailed.Wrap(err)
+	}
+	InvalidateUserCache(id)
+	return nil
+}
+
+// checkDeletable, silme ve kalıcı silme işlemlerinin ortak korumasıdır: işlemi yapan
+// kullanıcı bilinmeli, kendini ve SYSTEM_USER_EMAIL ile tanımlı sistem kullanıcısını
+// silememelidir.
+func checkDeletable(ctx context.Context, user *models.User) error {
+	currentUserID, ok := ctx.Value(contextUserIDKey).(uint)
+	if !ok || currentUserID == 0 {
+		return ErrMissingActor
+	}
+	if currentUserID == user.ID {
+		return ErrCannotDeleteSelf
+	}
+	if isSystemUser(user) {
+		logconfig.Log.Warn("Sistem kullanıcısını silme denemesi reddedildi", zap.Uint("user_id", user.ID), zap.Uint("actor_id", currentUserID))
+		return ErrSystemUserProtected
+	}
+	return nil
+}
+
+// manageableUser, işlemi yapan kullanıcının hedef kullanıcıyı yönetip yönetemeyeceğini
+// kontrol eder: sistem kullanıcısı ve işlemi yapanın sahip olmadığı bir yetkiye sahip
+// kullanıcılar reddedilir. Yetkiler API tokenı kapsamıyla birlikte değerlendirilir.
+func (s *UserService) manageableUser(ctx context.Context, id uint) (*models.User, error) {
+	target, err := s.repo.GetUserWithPermissions(id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		logconfig.Log.Error("Kullanıcı alınamadı", zap.Uint("user_id", id), zap.Error(err))
+		return nil, ErrUserUpdateFailed.Wrap(err)
+	}
+	if isSystemUser(target) {
+		return nil, ErrSystemUserReadOnly
+	}
+	for _, role := range target.Roles {
+		for _, permission := range role.Permissions {
+			if !auth.CanContext(ctx, permission.Name) {
+				return nil, ErrUserOutranksActor
+			}
+		}
+	}
+	return target, nil
+}
+
+func isSystemUser(user *models.User) bool {
+	email := strings.TrimSpace(os.Getenv("SYSTEM_USER_EMAIL"))
+	return email != "" && user.Type == models.Dashboard && strings.EqualFold(user.Email, email)
+}
+
+// PurgeTrashedUsers, age süresinden daha uzun süredir çöp kutusunda olan kullanıcıları
+// kalıcı olarak siler.
+func (s *UserService) PurgeTrashedUsers(age time.Duration) (int64, error) {
+	count, err := s.repo.PurgeTrashedUsers(age)
+	if err != nil {
+		logconfig.Log.Error("Çöp kutusundaki kullanıcılar temizlenemedi", zap.Duration("age", age), zap.Error(err))
+		return 0, ErrUserPurgeFailed.Wrap(err)
+	}
+	if count > 0 {
+		ResetUserCache()
+	}
+	return count, nil
 }
 
 func (s *UserService) GetUserCount() (int64, error) {
 	return s.repo.GetUserCount()
 }
 
+func (s *UserService) SyncUserRoles(ctx context.Context, userID uint, roleIDs []uint) error {
+	if err := s.repo.ReplaceUserRoles(ctx, userID, roleIDs); err != nil {
+		logconfig.Log.Error("Kullanıcı rolleri kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrUserRolesFailed.Wrap(err)
+	}
+	InvalidateUserCache(userID)
+	return nil
+}
+
+func (s *UserService) RequireTwoFactorForDashboardUsers(ctx context.Context) error {
+	currentUserID, _ := ctx.Value(contextUserIDKey).(uint)
+
+	condition := map[string]interface{}{"type": models.Dashboard}
+	data := map[string]interface{}{"two_factor_required": true}
+	if err := s.repo.BulkUpdateUsers(ctx, condition, data, currentUserID); err != nil {
+		logconfig.Log.Error("Yöneticiler için iki adımlı doğrulama zorunlu kılınamadı", zap.Error(err))
+		return ErrRequireTwoFactorFailed.Wrap(err)
+	}
+	ResetUserCache()
+	return nil
+}
+
+func validUserType(t models.UserType) bool {
+	return t == models.Dashboard || t == models.Panel
+}
+
 var _ IUserService = (*UserService)(nil)
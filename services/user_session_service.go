@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/configs/sessionconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/ttlcache"
+	"zatrano/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserSessionNotFound = apperrors.NotFound("user_session_not_found", "Oturum bulunamadı.")
+	ErrUserSessionGeneric  = apperrors.Internal("user_session_failed", "Oturumlar getirilirken bir hata oluştu.")
+)
+
+const maxUserAgentLength = 512
+
+// sessionTouches, son görülme zamanının her istekte yazılmasını engeller.
+var sessionTouches = ttlcache.New[string, struct{}](
+	time.Duration(envconfig.GetEnvAsInt("SESSION_TOUCH_INTERVAL_SECONDS", 60)) * time.Second,
+)
+
+type IUserSessionService interface {
+	Track(ctx context.Context, userID uint, sessionID, ip, userAgent string) error
+	Touch(userID uint, sessionID, ip, userAgent string)
+	GetActiveSessions(userID uint) ([]models.UserSession, error)
+	Revoke(ctx context.Context, userID, id uint) (*models.UserSession, error)
+	RevokeAll(ctx context.Context, userID uint, exceptSessionID string) (int, error)
+	Forget(ctx context.Context, sessionID string) error
+}
+
+type UserSessionService struct {
+	repo repositories.IUserSessionRepository
+}
+
+func NewUserSessionService() IUserSessionService {
+	return &UserSessionService{repo: repositories.NewUserSessionRepository()}
+}
+
+func (s *UserSessionService) Track(ctx context.Context, userID uint, sessionID, ip, userAgent string) error {
+	if userID == 0 || sessionID == "" {
+		return nil
+	}
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	now := time.Now().UTC()
+	if err := s.repo.Upsert(ctx, &models.UserSession{
+		SessionID:  sessionID,
+		UserID:     userID,
+		IPAddress:  ip,
+		UserAgent:  userAgent,
+		LastSeenAt: now,
+	}); err != nil {
+		logconfig.Log.Error("Oturum kaydı yazılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return err
+	}
+	sessionTouches.Set(sessionID, struct{}{})
+
+	if err := s.repo.DeleteStaleByUser(ctx, userID, now.Add(-sessionconfig.Lifetime())); err != nil {
+		logconfig.Log.Warn("Süresi dolmuş oturum kayıtları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *UserSessionService) Touch(userID uint, sessionID, ip, userAgent string) {
+	if _, ok := sessionTouches.Get(sessionID); ok {
+		return
+	}
+	_ = s.Track(context.Background(), userID, sessionID, ip, userAgent)
+}
+
+func (s *UserSessionService) GetActiveSessions(userID uint) ([]models.UserSession, error) {
+	since := time.Now().UTC().Add(-sessionconfig.Lifetime())
+	userSessions, err := s.repo.GetActiveByUser(userID, since)
+	if err != nil {
+		logconfig.Log.Error("Aktif oturumlar alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrUserSessionGeneric.Wrap(err)
+	}
+	return userSessions, nil
+}
+
+func (s *UserSessionService) Revoke(ctx context.Context, userID, id uint) (*models.UserSession, error) {
+	userSession, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserSessionNotFound
+		}
+		return nil, err
+	}
+	if userSession.UserID != userID {
+		return nil, ErrUserSessionNotFound
+	}
+
+	if err := s.end(ctx, []string{userSession.SessionID}); err != nil {
+		return nil, err
+	}
+	return userSession, nil
+}
+
+// RevokeAll kullanıcının tüm oturumlarını sonlandırır. exceptSessionID verilirse o oturum korunur.
+func (s *UserSessionService) RevokeAll(ctx context.Context, userID uint, exceptSessionID string) (int, error) {
+	sessionIDs, err := s.repo.GetSessionIDsByUser(userID, exceptSessionID)
+	if err != nil {
+		logconfig.Log.Error("Kullanıcı oturumları alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, err
+	}
+	if err := s.end(ctx, sessionIDs); err != nil {
+		return 0, err
+	}
+	logconfig.Log.Info("Kullanıcı oturumları sonlandırıldı", zap.Uint("user_id", userID), zap.Int("count", len(sessionIDs)))
+	return len(sessionIDs), nil
+}
+
+func (s *UserSessionService) Forget(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	sessionTouches.Delete(sessionID)
+	return s.repo.DeleteBySessionIDs(ctx, []string{sessionID})
+}
+
+func (s *UserSessionService) end(ctx context.Context, sessionIDs []string) error {
+	for _, sessionID := range sessionIDs {
+		if err := sessionconfig.DeleteSessionByID(sessionID); err != nil {
+			logconfig.Log.Error("Oturum depodan silinemedi", zap.Error(err))
+			return err
+		}
+		sessionTouches.Delete(sessionID)
+	}
+	return s.repo.DeleteBySessionIDs(ctx, sessionIDs)
+}
+
+var _ IUserSessionService = (*UserSessionService)(nil)
@@ -0,0 +1,350 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"zatrano/configs/envconfig"
+	"zatrano/configs/logconfig"
+	"zatrano/models"
+	"zatrano/pkg/apperrors"
+	"zatrano/pkg/passkey"
+	"zatrano/repositories"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrPasskeyUnavailable  = apperrors.Unavailable("passkey_unavailable", "Passkey desteği şu anda kullanılamıyor.")
+	ErrPasskeyVerification = apperrors.Unprocessable("passkey_verification_failed", "Passkey doğrulanamadı. Lütfen tekrar deneyin.")
+	ErrPasskeyNotFound     = apperrors.NotFound("passkey_not_found", "Passkey bulunamadı.")
+	ErrPasskeyGeneric      = apperrors.Internal("passkey_failed", "Passkey işlemi sırasında bir hata oluştu.")
+)
+
+const maxPasskeyNameLength = 100
+
+// passkeyCeremony, WEBAUTHN_* ortam değişkenlerinden ilk kullanımda oluşturulur.
+// RP ID ve origin verilmezse APP_BASE_URL'den türetilir.
+var passkeyCeremony = sync.OnceValues(func() (*passkey.Ceremony, error) {
+	baseURL := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+
+	rpID := os.Getenv("WEBAUTHN_RP_ID")
+	if rpID == "" {
+		if parsed, err := url.Parse(baseURL); err == nil {
+			rpID = parsed.Hostname()
+		}
+	}
+
+	var origins []string
+	for _, origin := range strings.Split(envconfig.GetEnvWithDefault("WEBAUTHN_ORIGINS", baseURL), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	ceremony, err := passkey.New(passkey.Config{
+		RPID:          rpID,
+		RPDisplayName: envconfig.GetEnvWithDefault("WEBAUTHN_RP_NAME", "Zatrano"),
+		RPOrigins:     origins,
+		Timeout:       time.Duration(envconfig.GetEnvAsInt("WEBAUTHN_TIMEOUT_SECONDS", 120)) * time.Second,
+	})
+	if err != nil {
+		logconfig.Log.Error("WebAuthn yapılandırması geçersiz", zap.String("rp_id", rpID), zap.Error(err))
+		return nil, err
+	}
+	return ceremony, nil
+})
+
+// Begin metotlarının döndürdüğü ceremonyState, tören bitene kadar oturumda saklanacak opak değerdir.
+type IWebAuthnService interface {
+	BeginRegistration(user *models.User) (options interface{}, ceremonyState string, err error)
+	FinishRegistration(ctx context.Context, user *models.User, ceremonyState, name string, body io.Reader) (*models.WebAuthnCredential, error)
+	BeginLogin() (options interface{}, ceremonyState string, err error)
+	FinishLogin(ctx context.Context, ceremonyState string, body io.Reader) (*models.User, error)
+	GetCredentials(userID uint) ([]models.WebAuthnCredential, error)
+	Revoke(ctx context.Context, userID, id uint) (*models.WebAuthnCredential, error)
+}
+
+type WebAuthnService struct {
+	repo     repositories.IWebAuthnCredentialRepository
+	authRepo repositories.IAuthRepository
+}
+
+func NewWebAuthnService() IWebAuthnService {
+	return &WebAuthnService{
+		repo:     repositories.NewWebAuthnCredentialRepository(),
+		authRepo: repositories.NewAuthRepository(),
+	}
+}
+
+func (s *WebAuthnService) account(user *models.User) (*passkey.Account, []models.WebAuthnCredential, error) {
+	stored, err := s.repo.GetByUser(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	credentials := make([]webauthn.Credential, 0, len(stored))
+	for _, credential := range stored {
+		credentials = append(credentials, toWebAuthnCredential(credential))
+	}
+	return &passkey.Account{
+		UserID:      user.ID,
+		Name:        user.Email,
+		DisplayName: user.Name,
+		Credentials: credentials,
+	}, stored, nil
+}
+
+func (s *WebAuthnService) BeginRegistration(user *models.User) (interface{}, string, error) {
+	ceremony, err := passkeyCeremony()
+	if err != nil {
+		return nil, "", ErrPasskeyUnavailable
+	}
+
+	account, _, err := s.account(user)
+	if err != nil {
+		logconfig.Log.Error("Passkey listesi alınamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, "", ErrPasskeyGeneric
+	}
+
+	options, sessionData, err := ceremony.BeginRegistration(account)
+	if err != nil {
+		logconfig.Log.Error("Passkey kaydı başlatılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, "", ErrPasskeyGeneric
+	}
+	state, err := encodeCeremonyState(sessionData)
+	if err != nil {
+		return nil, "", ErrPasskeyGeneric
+	}
+	return options, state, nil
+}
+
+func (s *WebAuthnService) FinishRegistration(ctx context.Context, user *models.User, ceremonyState, name string, body io.Reader) (*models.WebAuthnCredential, error) {
+	ceremony, err := passkeyCeremony()
+	if err != nil {
+		return nil, ErrPasskeyUnavailable
+	}
+	sessionData, err := decodeCeremonyState(ceremonyState)
+	if err != nil {
+		return nil, ErrPasskeyVerification
+	}
+
+	account, _, err := s.account(user)
+	if err != nil {
+		return nil, ErrPasskeyGeneric
+	}
+
+	credential, err := ceremony.FinishRegistration(account, *sessionData, body)
+	if err != nil {
+		logconfig.Log.Warn("Passkey kaydı doğrulanamadı", zap.Uint("user_id", user.ID), zap.Error(passkeyErrorDetail(err)))
+		return nil, ErrPasskeyVerification
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Passkey " + time.Now().Format("02.01.2006")
+	}
+	if len([]rune(name)) > maxPasskeyNameLength {
+		name = string([]rune(name)[:maxPasskeyNameLength])
+	}
+
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	record := &models.WebAuthnCredential{
+		UserID:          user.ID,
+		Name:            name,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      strings.Join(transports, ","),
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}
+	if err := s.repo.Create(ctx, record); err != nil {
+		logconfig.Log.Error("Passkey kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrPasskeyGeneric
+	}
+
+	logconfig.Log.Info("Passkey kaydedildi", zap.Uint("user_id", user.ID), zap.Uint("credential_id", record.ID))
+	return record, nil
+}
+
+func (s *WebAuthnService) BeginLogin() (interface{}, string, error) {
+	ceremony, err := passkeyCeremony()
+	if err != nil {
+		return nil, "", ErrPasskeyUnavailable
+	}
+
+	options, sessionData, err := ceremony.BeginLogin()
+	if err != nil {
+		logconfig.Log.Error("Passkey girişi başlatılamadı", zap.Error(err))
+		return nil, "", ErrPasskeyGeneric
+	}
+	state, err := encodeCeremonyState(sessionData)
+	if err != nil {
+		return nil, "", ErrPasskeyGeneric
+	}
+	return options, state, nil
+}
+
+func (s *WebAuthnService) FinishLogin(ctx context.Context, ceremonyState string, body io.Reader) (*models.User, error) {
+	ceremony, err := passkeyCeremony()
+	if err != nil {
+		return nil, ErrPasskeyUnavailable
+	}
+	sessionData, err := decodeCeremonyState(ceremonyState)
+	if err != nil {
+		return nil, ErrPasskeyVerification
+	}
+
+	var (
+		user   *models.User
+		stored []models.WebAuthnCredential
+	)
+	lookup := func(userID uint, _ []byte) (*passkey.Account, error) {
+		found, err := s.authRepo.FindUserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		account, credentials, err := s.account(found)
+		if err != nil {
+			return nil, err
+		}
+		user, stored = found, credentials
+		return account, nil
+	}
+
+	_, credential, err := ceremony.FinishLogin(*sessionData, body, lookup)
+	if err != nil {
+		fields := []zap.Field{zap.Error(passkeyErrorDetail(err))}
+		if user != nil {
+			fields = append(fields, zap.Uint("user_id", user.ID))
+		}
+		logconfig.Log.Warn("Passkey girişi doğrulanamadı", fields...)
+		return nil, ErrPasskeyVerification
+	}
+
+	if !user.Status {
+		logconfig.Log.Warn("Passkey girişi: kullanıcı aktif değil", zap.Uint("user_id", user.ID))
+		return nil, ErrUserInactive
+	}
+
+	for _, record := range stored {
+		if bytes.Equal(record.CredentialID, credential.ID) {
+			if err := s.repo.RecordUse(ctx, record.ID, credential.Authenticator.SignCount, credential.Flags.BackupState); err != nil {
+				logconfig.Log.Warn("Passkey kullanım bilgisi güncellenemedi", zap.Uint("credential_id", record.ID), zap.Error(err))
+			}
+			break
+		}
+	}
+
+	logconfig.Log.Info("Passkey ile kimlik doğrulama başarılı", zap.Uint("user_id", user.ID))
+	return user, nil
+}
+
+func (s *WebAuthnService) GetCredentials(userID uint) ([]models.WebAuthnCredential, error) {
+	credentials, err := s.repo.GetByUser(userID)
+	if err != nil {
+		logconfig.Log.Error("Passkey listesi alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrPasskeyGeneric
+	}
+	return credentials, nil
+}
+
+func (s *WebAuthnService) Revoke(ctx context.Context, userID, id uint) (*models.WebAuthnCredential, error) {
+	credentials, err := s.GetCredentials(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *models.WebAuthnCredential
+	for i := range credentials {
+		if credentials[i].ID == id {
+			target = &credentials[i]
+			break
+		}
+	}
+	if target == nil {
+		return nil, ErrPasskeyNotFound
+	}
+
+	deleted, err := s.repo.DeleteForUser(ctx, userID, id)
+	if err != nil {
+		logconfig.Log.Error("Passkey silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrPasskeyGeneric
+	}
+	if deleted == 0 {
+		return nil, ErrPasskeyNotFound
+	}
+	logconfig.Log.Info("Passkey kaldırıldı", zap.Uint("user_id", userID), zap.Uint("credential_id", id))
+	return target, nil
+}
+
+func toWebAuthnCredential(record models.WebAuthnCredential) webauthn.Credential {
+	var transports []protocol.AuthenticatorTransport
+	for _, transport := range strings.Split(record.Transports, ",") {
+		if transport != "" {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+	}
+	return webauthn.Credential{
+		ID:              record.CredentialID,
+		PublicKey:       record.PublicKey,
+		AttestationType: record.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			BackupEligible: record.BackupEligible,
+			BackupState:    record.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    record.AAGUID,
+			SignCount: record.SignCount,
+		},
+	}
+}
+
+func encodeCeremonyState(sessionData *webauthn.SessionData) (string, error) {
+	encoded, err := json.Marshal(sessionData)
+	if err != nil {
+		logconfig.Log.Error("WebAuthn tören verisi kodlanamadı", zap.Error(err))
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func decodeCeremonyState(state string) (*webauthn.SessionData, error) {
+	if state == "" {
+		return nil, errors.New("tören verisi bulunamadı")
+	}
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal([]byte(state), &sessionData); err != nil {
+		return nil, err
+	}
+	return &sessionData, nil
+}
+
+// passkeyErrorDetail, kütüphanenin protocol.Error içindeki ayrıntıyı log'a taşır.
+func passkeyErrorDetail(err error) error {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.DevInfo != "" {
+		return errors.New(protocolErr.Details + ": " + protocolErr.DevInfo)
+	}
+	return err
+}
+
+var _ IWebAuthnService = (*WebAuthnService)(nil)